@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	homer "github.com/rajsinghtech/homer-operator.git/pkg/homer"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -30,16 +31,206 @@ type DashboardSpec struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	// Foo is an example field of Dashboard. Edit dashboard_types.go to remove/update
-	ConfigMap ConfigMap `json:"configMap,omitempty"`
+	ConfigMap   ConfigMap         `json:"configMap,omitempty"`
 	HomerConfig homer.HomerConfig `json:"homerConfig,omitempty"`
+	// Paused stops the operator from changing the Dashboard's resources, e.g. while
+	// its ConfigMap is edited by hand. Deleting a paused Dashboard still cleans up.
+	Paused bool `json:"paused,omitempty"`
+	// IngressClassName limits discovery to ingresses of this class. Empty includes every class.
+	IngressClassName string `json:"ingressClassName,omitempty"`
+	// DomainFilters limits discovery to ingress hosts matching one of the filters: a
+	// domain with its subdomains (example.com), a glob (*.staging.example.com), or a
+	// regular expression between slashes (/^app-[0-9]+\.example\.com$/). A leading !
+	// excludes the matching hosts, and exclusions win over inclusions. Empty includes
+	// every host.
+	DomainFilters []string `json:"domainFilters,omitempty"`
+	// ExcludeHostPatterns leaves out the hosts matching one of the patterns, written
+	// like domainFilters, e.g. *.canary.example.com.
+	ExcludeHostPatterns []string `json:"excludeHostPatterns,omitempty"`
+	// ExcludeNamePatterns leaves out the ingresses whose name matches one of the patterns,
+	// each a glob such as acme-http-solver-*, a regular expression between slashes, or a name.
+	ExcludeNamePatterns []string `json:"excludeNamePatterns,omitempty"`
+	// AutoKeywordsFromLabels lists label keys whose values are added to the search keywords
+	// of discovered items, together with their namespace and the operator's cluster name.
+	AutoKeywordsFromLabels []string `json:"autoKeywordsFromLabels,omitempty"`
+	// IncludeACMESolvers keeps the short-lived ingresses cert-manager creates for HTTP-01
+	// challenges, labeled acme.cert-manager.io/http01-solver=true, which are skipped by default.
+	IncludeACMESolvers bool `json:"includeACMESolvers,omitempty"`
+	// Secrets injects values read from Secrets in the Dashboard's namespace into the config.
+	Secrets SecretsConfig `json:"secrets,omitempty"`
+	// AllowedItemFields restricts the item fields that item.homer.rajsingh.info/<field>
+	// annotations may set, e.g. ["Subtitle", "Tag"]. Empty allows every field.
+	AllowedItemFields []string `json:"allowedItemFields,omitempty"`
+	// AllowedServiceFields restricts the service fields that service.homer.rajsingh.info/<field>
+	// annotations may set. Empty allows every field.
+	AllowedServiceFields []string `json:"allowedServiceFields,omitempty"`
+	// AllowedSmartCardTypes restricts the smart card types items may use, e.g. ["PiHole"].
+	// Items of any other type lose their type, endpoint and API key and render as plain
+	// links. Empty allows every type.
+	AllowedSmartCardTypes []string `json:"allowedSmartCardTypes,omitempty"`
+	// ValidationLevel controls how problems in discovered resources are handled.
+	// warn logs them and keeps going, strict also drops the offending values and fails
+	// the reconcile when the rendered config has keys or values Homer does not understand.
+	// +kubebuilder:validation:Enum=warn;strict
+	// +kubebuilder:default=warn
+	ValidationLevel string `json:"validationLevel,omitempty"`
+	// ThemeFrom loads homerConfig.theme and homerConfig.colors from a ConfigMap.
+	// Values set inline in homerConfig override the referenced ones field by field.
+	ThemeFrom *ThemeSource `json:"themeFrom,omitempty"`
+	// YAMLKeyOverrides renames keys in the generated config.yml, e.g. {"apikey": "apiKey"},
+	// for Homer fields the operator does not spell the way a newer Homer expects.
+	YAMLKeyOverrides map[string]string `json:"yamlKeyOverrides,omitempty"`
+	// OutputStyle of the generated config.yml. minimal leaves out empty values and
+	// defaults values equal to Homer's defaults, which keeps the ConfigMap small and reviewable.
+	// +kubebuilder:validation:Enum=full;minimal
+	// +kubebuilder:default=full
+	OutputStyle string `json:"outputStyle,omitempty"`
+	// ConfigKey is the key of the generated config in the Homer ConfigMap, and so its
+	// file name under /www/assets, for Homer images that expect e.g. config.yaml.
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	// +kubebuilder:default=config.yml
+	ConfigKey string `json:"configKey,omitempty"`
+	// SortStrategy orders the discovered items of each service group. "recent" puts the
+	// items whose source was updated last first; by default items keep the discovery order.
+	// +kubebuilder:validation:Enum=recent
+	SortStrategy string `json:"sortStrategy,omitempty"`
+	// PreferStatusHostnames uses the hostname or IP an ingress's load balancer reports for
+	// rules with an empty or wildcard host, instead of the rule host.
+	PreferStatusHostnames bool `json:"preferStatusHostnames,omitempty"`
+	// MaxItemsPerService caps the number of items in each service group. Discovered items
+	// beyond the cap are dropped; items defined in homerConfig are always kept. 0 means no cap.
+	// +kubebuilder:validation:Minimum=0
+	MaxItemsPerService int `json:"maxItemsPerService,omitempty"`
+	// DiscoverEndpointSlices adds an item for every ready endpoint of the selected
+	// EndpointSlices, grouped by the Service owning the slice. The operator must run
+	// with --enable-endpointslices.
+	DiscoverEndpointSlices bool `json:"discoverEndpointSlices,omitempty"`
+	// EndpointSliceSelector selects the EndpointSlices to discover. Defaults to the
+	// slices of headless Services.
+	EndpointSliceSelector *metav1.LabelSelector `json:"endpointSliceSelector,omitempty"`
+	// DefaultScheme is the URL scheme of items discovered from ingresses, for clusters
+	// terminating TLS in front of the ingress. When empty, https is used for ingresses
+	// with a TLS block. The item.homer.rajsingh.info/scheme annotation overrides it.
+	// +kubebuilder:validation:Enum=http;https
+	DefaultScheme string `json:"defaultScheme,omitempty"`
+	// ServiceGrouping decides the service group of items discovered from ingresses.
+	// namespace groups them by namespace; none puts them all into one "Services" group,
+	// e.g. for a dashboard of a single namespace. The service.homer.rajsingh.info/name
+	// annotation overrides either.
+	// +kubebuilder:validation:Enum=namespace;none
+	// +kubebuilder:default=namespace
+	ServiceGrouping string `json:"serviceGrouping,omitempty"`
+	// Service customizes the Service exposing the Homer Deployment.
+	Service *ServiceConfig `json:"service,omitempty"`
+	// Deployment adds metadata to the Homer Deployment.
+	Deployment *DeploymentConfig `json:"deployment,omitempty"`
+	// ItemNameTemplate is a Go template naming the items of ingresses with several
+	// hosts, which otherwise all carry the ingress name. It sees .Name, .Namespace and
+	// .Host and the functions trimSuffix, trimPrefix and replace, e.g.
+	// `{{ .Name }}-{{ trimSuffix .Host ".example.com" }}`. An
+	// item.homer.rajsingh.info/Name annotation still wins.
+	ItemNameTemplate string `json:"itemNameTemplate,omitempty"`
+	// DefaultIngressLogo is the logo of items discovered from ingresses, e.g. an icon
+	// served from the cluster for air-gapped installs. Empty uses the Kubernetes ingress icon.
+	DefaultIngressLogo string `json:"defaultIngressLogo,omitempty"`
+	// DefaultServiceLogo is the logo of service groups created for discovered items.
+	// Empty uses the Kubernetes namespace icon.
+	DefaultServiceLogo string `json:"defaultServiceLogo,omitempty"`
+	// PodTemplate customizes scheduling and security of the Homer pod.
+	PodTemplate *PodTemplate `json:"podTemplate,omitempty"`
+	// ExtraVolumes are added to the Homer pod, e.g. a PersistentVolumeClaim holding large logos.
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+	// ExtraVolumeMounts are added to the Homer container, e.g. under /www/assets/icons.
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+	// BaseURL is the path Homer is served under behind a path-based ingress, e.g. /homer.
+	// It is passed to the Homer image as its SUBFOLDER.
+	// +kubebuilder:validation:Pattern=`^/[-._~/a-zA-Z0-9]*$`
+	BaseURL string `json:"baseURL,omitempty"`
+	// RestartOnConfigChange rolls the Homer pods whenever the generated config changes,
+	// instead of relying on Homer to load the updated ConfigMap.
+	RestartOnConfigChange bool `json:"restartOnConfigChange,omitempty"`
+	// HomerAssetsPath is the directory the Homer image loads its config and serves its
+	// assets from. It defaults to /www/assets, the directory of the b4bz/homer image.
+	// +kubebuilder:validation:Pattern=`^/[-._~/a-zA-Z0-9]*$`
+	HomerAssetsPath string `json:"homerAssetsPath,omitempty"`
+	// VerifyConfigServed adds a startup probe fetching the config through Homer, so an
+	// image serving its assets from another directory than HomerAssetsPath fails to start
+	// with a probe error instead of serving an empty dashboard.
+	VerifyConfigServed bool `json:"verifyConfigServed,omitempty"`
+	// Assets are files served by Homer under assets/custom, e.g. logos referenced as
+	// assets/custom/logo.png. They are stored in the <name>-homer-assets ConfigMap.
+	Assets *AssetsConfig `json:"assets,omitempty"`
+	// EmitInventory writes the <name>-homer-inventory ConfigMap listing, as JSON, the
+	// resource each discovered item came from.
+	EmitInventory bool `json:"emitInventory,omitempty"`
+	// Debug writes the <name>-homer-debug ConfigMap listing, as JSON, every ingress the
+	// Dashboard evaluated and the first filter that excluded it, or "included".
+	Debug bool `json:"debug,omitempty"`
+	// DeploymentRef names another Dashboard in the namespace whose Homer Deployment serves
+	// this Dashboard as the additional page assets/<name>.yml, opened as #<name>. Only the
+	// ConfigMap is created for this Dashboard, no Deployment or Service.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	DeploymentRef string `json:"deploymentRef,omitempty"`
 }
 
 // DashboardStatus defines the observed state of Dashboard
 type DashboardStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
+
+	// Conditions represent the latest available observations of the Dashboard's state.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// DroppedItems is the number of discovered items left out because of spec.maxItemsPerService.
+	DroppedItems int `json:"droppedItems,omitempty"`
+	// SecretErrors lists the secret references that could not be resolved and were
+	// left out because spec.secrets.failurePolicy is Ignore.
+	SecretErrors []string `json:"secretErrors,omitempty"`
 }
 
+// Output styles accepted by DashboardSpec.OutputStyle.
+const (
+	OutputStyleFull    = "full"
+	OutputStyleMinimal = "minimal"
+)
+
+// Service groupings accepted by DashboardSpec.ServiceGrouping.
+const (
+	ServiceGroupingNamespace = "namespace"
+	ServiceGroupingNone      = "none"
+)
+
+// Failure policies accepted by SecretsConfig.FailurePolicy.
+const (
+	SecretFailurePolicyFail   = "Fail"
+	SecretFailurePolicyIgnore = "Ignore"
+)
+
+// Validation levels accepted by DashboardSpec.ValidationLevel.
+const (
+	ValidationLevelWarn   = "warn"
+	ValidationLevelStrict = "strict"
+)
+
+// Condition types reported on a Dashboard.
+const (
+	// ConditionReady is true when the config is rendered and the Deployment is available.
+	ConditionReady = "Ready"
+	// ConditionConfigRendered is true when the Homer ConfigMap was generated and applied.
+	ConditionConfigRendered = "ConfigRendered"
+	// ConditionDeploymentAvailable mirrors the Available condition of the Homer Deployment.
+	ConditionDeploymentAvailable = "DeploymentAvailable"
+	// ConditionConfigValid is false when the rendered config has validation warnings,
+	// such as items from different sources sharing a name in one service group.
+	ConditionConfigValid = "ConfigValid"
+	// ConditionPaused is true while spec.paused stops reconciliation of the Dashboard.
+	ConditionPaused = "Paused"
+	// ConditionManualOverride is true while the Homer ConfigMap carries the
+	// homer.rajsingh.info/manual-override annotation and so is not regenerated.
+	ConditionManualOverride = "ManualOverride"
+)
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 
@@ -68,4 +259,86 @@ func init() {
 type ConfigMap struct {
 	Name string `json:"name,omitempty"`
 	Key  string `json:"key,omitempty"`
-}
\ No newline at end of file
+}
+
+// ServiceConfig holds the fields of the Homer Service that can be overridden.
+type ServiceConfig struct {
+	// Type of the Service, e.g. LoadBalancer to expose the dashboard without an Ingress.
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +kubebuilder:default=ClusterIP
+	Type corev1.ServiceType `json:"type,omitempty"`
+	// IPFamilyPolicy of the Service, for IPv6-only and dual-stack clusters.
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+	// IPFamilies of the Service, in order of preference.
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
+	// Annotations added to the Service, e.g. external-dns.alpha.kubernetes.io/hostname.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// DeploymentConfig holds metadata added to the Homer Deployment.
+type DeploymentConfig struct {
+	// Annotations added to the Deployment.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Labels added to the Deployment. Labels set by the operator cannot be overridden.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// PodTemplate holds the fields of the Homer pod that can be overridden. Unset
+// fields leave the operator's defaults in place.
+type PodTemplate struct {
+	// SecurityContext is the security context of the Homer pod.
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+	// ContainerSecurityContext is the security context of the Homer container.
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+	// NodeSelector constrains the Homer pod to nodes with these labels.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations lets the Homer pod schedule onto tainted nodes.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Affinity sets the scheduling affinity of the Homer pod.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// PriorityClassName sets the priority class of the Homer pod.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+type SecretsConfig struct {
+	// ProxyHeaders maps a header name to the Secret key holding its value.
+	// Resolved values are set on homerConfig.proxy.headers.
+	ProxyHeaders map[string]SecretKeyRef `json:"proxyHeaders,omitempty"`
+	// Optional skips references to Secrets or keys that do not exist yet, e.g. while
+	// external-secrets-operator is still syncing them, instead of failing the reconcile.
+	// Referenced Secrets are watched, so a Secret that appears is picked up.
+	Optional bool `json:"optional,omitempty"`
+	// FailurePolicy decides what a failed secret resolution does. Fail stops the
+	// reconcile; Ignore renders the config without the value and records the error
+	// in status.secretErrors.
+	// +kubebuilder:validation:Enum=Fail;Ignore
+	// +kubebuilder:default=Fail
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+}
+
+// AssetsConfig holds files the operator serves next to the Homer config.
+type AssetsConfig struct {
+	// Files maps file names to their base64 encoded content. Together they must fit
+	// into a single ConfigMap, which is limited to 1MiB.
+	Files map[string][]byte `json:"files,omitempty"`
+	// Stylesheets maps CSS file names, e.g. custom.css, to their content. They are stored
+	// next to Files and added to homerConfig.stylesheet.
+	Stylesheets map[string]string `json:"stylesheets,omitempty"`
+}
+
+type SecretKeyRef struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+	// Decode decodes the value once more, for secrets whose value is itself base64 encoded.
+	// +kubebuilder:validation:Enum=base64
+	Decode string `json:"decode,omitempty"`
+	// ValueFrom extracts one field from a value holding JSON, given as a dotted path
+	// such as token or $.auth.token. It applies after Decode.
+	ValueFrom string `json:"valueFrom,omitempty"`
+}
+
+type ThemeSource struct {
+	// ConfigMapRef selects a ConfigMap in the Dashboard's namespace. The key, theme.yml
+	// by default, holds YAML with a theme and colors block.
+	ConfigMapRef ConfigMap `json:"configMapRef"`
+}
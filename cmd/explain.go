@@ -0,0 +1,123 @@
+/*
+Copyright 2024 RajSingh.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	homerv1alpha1 "github.com/rajsinghtech/homer-operator.git/api/v1alpha1"
+	"github.com/rajsinghtech/homer-operator.git/internal/controller"
+	homer "github.com/rajsinghtech/homer-operator.git/pkg/homer"
+)
+
+// runExplain implements `homer-operator explain`, which lists every ingress evaluated
+// for a Dashboard, whether it passed the Dashboard's filters and where it landed.
+func runExplain(args []string) int {
+	fs := flag.NewFlagSet("explain", flag.ExitOnError)
+	dashboardRef := fs.String("dashboard", "", "The Dashboard to explain, as namespace/name.")
+	kubeconfig := fs.String("kubeconfig", "", "Path to a kubeconfig. Defaults to in-cluster config or $KUBECONFIG.")
+	_ = fs.Parse(args)
+
+	namespace, name, ok := strings.Cut(*dashboardRef, "/")
+	if !ok || namespace == "" || name == "" {
+		fmt.Fprintln(os.Stderr, "explain: --dashboard must be given as namespace/name")
+		return 2
+	}
+
+	var restConfig *rest.Config
+	var err error
+	if *kubeconfig != "" {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", *kubeconfig)
+	} else {
+		restConfig, err = ctrl.GetConfig()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "explain: unable to load kubeconfig: %v\n", err)
+		return 1
+	}
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "explain: unable to create client: %v\n", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	var dashboard homerv1alpha1.Dashboard
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &dashboard); err != nil {
+		fmt.Fprintf(os.Stderr, "explain: unable to fetch Dashboard %s: %v\n", *dashboardRef, err)
+		return 1
+	}
+	var ingresses networkingv1.IngressList
+	if err := c.List(ctx, &ingresses); err != nil {
+		fmt.Fprintf(os.Stderr, "explain: unable to list Ingresses: %v\n", err)
+		return 1
+	}
+
+	if err := writeExplanation(os.Stdout, &dashboard, ingresses.Items); err != nil {
+		return 1
+	}
+	return 0
+}
+
+// writeExplanation writes one row per ingress with the outcome of the Dashboard's
+// filters and, for the included ones, the service groups they produced.
+func writeExplanation(out io.Writer, dashboard *homerv1alpha1.Dashboard, ingresses []networkingv1.Ingress) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tINGRESS\tINCLUDED\tREASON\tSERVICE GROUPS")
+	for _, ingress := range ingresses {
+		evaluation := controller.EvaluateIngress(dashboard, &ingress)
+		groups := "-"
+		if evaluation.Included() {
+			groups = strings.Join(serviceGroups(dashboard, ingress), ",")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%s\n", ingress.Namespace, ingress.Name,
+			evaluation.Included(), evaluation.Reason(), groups)
+	}
+	return w.Flush()
+}
+
+// serviceGroups renders the ingress on its own and reports the service groups,
+// or top navigation links, it produced.
+func serviceGroups(dashboard *homerv1alpha1.Dashboard, ingress networkingv1.Ingress) []string {
+	config := homer.HomerConfig{}
+	list := networkingv1.IngressList{Items: []networkingv1.Ingress{ingress}}
+	_ = homer.UpdateHomerConfig(&config, list, controller.DiscoveryOptions(dashboard, logr.Discard()))
+	var groups []string
+	for _, service := range config.Services {
+		groups = append(groups, service.Name)
+	}
+	for _, link := range config.Links {
+		groups = append(groups, "link:"+link.Name)
+	}
+	if len(groups) == 0 {
+		return []string{"(no items)"}
+	}
+	return groups
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 RajSingh.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	homerv1alpha1 "github.com/rajsinghtech/homer-operator.git/api/v1alpha1"
+)
+
+func explainIngress(name, host string, annotations map[string]string) networkingv1.Ingress {
+	return networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "apps", Annotations: annotations},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{Host: host}},
+		},
+	}
+}
+
+func TestWriteExplanation(t *testing.T) {
+	dashboard := &homerv1alpha1.Dashboard{
+		ObjectMeta: metav1.ObjectMeta{Name: "home", Namespace: "default"},
+		Spec: homerv1alpha1.DashboardSpec{
+			DomainFilters:       []string{"example.com"},
+			ExcludeNamePatterns: []string{"*-canary"},
+		},
+	}
+	ingresses := []networkingv1.Ingress{
+		explainIngress("grafana", "grafana.example.com", map[string]string{
+			"item.homer.rajsingh.info/groups": "Monitoring",
+		}),
+		explainIngress("wiki", "wiki.other.org", nil),
+		explainIngress("grafana-canary", "grafana-canary.example.com", nil),
+	}
+
+	var out bytes.Buffer
+	if err := writeExplanation(&out, dashboard, ingresses); err != nil {
+		t.Fatalf("writeExplanation returned an error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected a header and 3 rows, got %d lines:\n%s", len(lines), out.String())
+	}
+	want := [][]string{
+		{"NAMESPACE", "INGRESS", "INCLUDED", "REASON", "SERVICE", "GROUPS"},
+		{"apps", "grafana", "true", "included", "Monitoring"},
+		{"apps", "wiki", "false", "no", "host", "matches", "the", "domain", "filters", "-"},
+		{"apps", "grafana-canary", "false", "name", "matches", "the", "exclude", "name", "patterns", "-"},
+	}
+	for i, line := range lines {
+		if got := strings.Fields(line); strings.Join(got, " ") != strings.Join(want[i], " ") {
+			t.Errorf("Line %d: expected %q, got %q", i, want[i], got)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 RajSingh.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	homerv1alpha1 "github.com/rajsinghtech/homer-operator.git/api/v1alpha1"
+	homer "github.com/rajsinghtech/homer-operator.git/pkg/homer"
+)
+
+// runImport implements `homer-operator import`, which converts a hand-maintained Homer
+// config.yml into a Dashboard manifest with the config under spec.homerConfig.
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	file := fs.String("file", "", "The Homer config.yml to import.")
+	name := fs.String("name", "", "The name of the Dashboard.")
+	namespace := fs.String("namespace", "", "The namespace of the Dashboard. Empty leaves it to kubectl.")
+	_ = fs.Parse(args)
+
+	if *file == "" || *name == "" {
+		fmt.Fprintln(os.Stderr, "import: --file and --name must be given")
+		return 2
+	}
+	manifest, err := importDashboard(*file, *name, *namespace)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import: %v\n", err)
+		return 1
+	}
+	if _, err := os.Stdout.Write(manifest); err != nil {
+		return 1
+	}
+	return 0
+}
+
+// importDashboard loads the Homer config.yml in file and renders it as the manifest
+// of a Dashboard with the given name and, unless empty, namespace.
+func importDashboard(file, name, namespace string) ([]byte, error) {
+	config, err := homer.LoadConfigFromFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load %s: %w", file, err)
+	}
+	// The strict parse only serves to name the keys the lenient load left out.
+	if data, err := os.ReadFile(file); err == nil {
+		if _, err := homer.LoadHomerConfig(string(data)); err != nil {
+			fmt.Fprintf(os.Stderr, "import: warning: keys the Dashboard does not support were left out: %v\n", err)
+		}
+	}
+	metadata := map[string]string{"name": name}
+	if namespace != "" {
+		metadata["namespace"] = namespace
+	}
+	manifest, err := yaml.Marshal(map[string]interface{}{
+		"apiVersion": homerv1alpha1.GroupVersion.String(),
+		"kind":       "Dashboard",
+		"metadata":   metadata,
+		"spec":       map[string]interface{}{"homerConfig": config},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to render the Dashboard: %w", err)
+	}
+	return manifest, nil
+}
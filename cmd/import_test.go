@@ -0,0 +1,126 @@
+/*
+Copyright 2024 RajSingh.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+
+	homerv1alpha1 "github.com/rajsinghtech/homer-operator.git/api/v1alpha1"
+	homer "github.com/rajsinghtech/homer-operator.git/pkg/homer"
+)
+
+const importConfig = `title: "Home Lab"
+subtitle: "Apps"
+logo: "assets/logo.png"
+footer: '<p>Homer</p>'
+defaults:
+  layout: list
+  colorTheme: dark
+links:
+- name: "Github"
+  icon: "fab fa-github"
+  url: "https://github.com/bastienwirtz/homer"
+services:
+- name: "Media"
+  icon: "fas fa-film"
+  items:
+  - name: "Jellyfin"
+    url: "https://jellyfin.example.com"
+    subtitle: "Movies"
+    tag: "media"
+  - name: "Pi-hole"
+    url: "https://pihole.example.com"
+    type: "PiHole"
+    endpoint: "https://pihole.example.com/admin"
+`
+
+func writeImportConfig(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("unable to write %s: %v", file, err)
+	}
+	return file
+}
+
+func TestImportDashboard(t *testing.T) {
+	file := writeImportConfig(t, importConfig)
+	manifest, err := importDashboard(file, "home", "apps")
+	if err != nil {
+		t.Fatalf("importDashboard returned an error: %v", err)
+	}
+
+	var dashboard homerv1alpha1.Dashboard
+	if err := yaml.UnmarshalStrict(manifest, &dashboard); err != nil {
+		t.Fatalf("the manifest is not a valid Dashboard: %v\n%s", err, manifest)
+	}
+	if dashboard.APIVersion != homerv1alpha1.GroupVersion.String() || dashboard.Kind != "Dashboard" {
+		t.Errorf("expected a %s Dashboard, got %s %s", homerv1alpha1.GroupVersion, dashboard.APIVersion, dashboard.Kind)
+	}
+	if dashboard.Name != "home" || dashboard.Namespace != "apps" {
+		t.Errorf("expected apps/home, got %s/%s", dashboard.Namespace, dashboard.Name)
+	}
+	want, err := homer.LoadHomerConfig(importConfig)
+	if err != nil {
+		t.Fatalf("unable to load the sample config: %v", err)
+	}
+	if !reflect.DeepEqual(dashboard.Spec.HomerConfig, want) {
+		t.Errorf("expected the imported config to round-trip\nwant: %+v\ngot:  %+v", want, dashboard.Spec.HomerConfig)
+	}
+}
+
+func TestImportDashboardWithoutNamespace(t *testing.T) {
+	file := writeImportConfig(t, "title: \"Home\"\n")
+	manifest, err := importDashboard(file, "home", "")
+	if err != nil {
+		t.Fatalf("importDashboard returned an error: %v", err)
+	}
+	var dashboard homerv1alpha1.Dashboard
+	if err := yaml.Unmarshal(manifest, &dashboard); err != nil {
+		t.Fatalf("the manifest is not a valid Dashboard: %v", err)
+	}
+	if dashboard.Namespace != "" || dashboard.Spec.HomerConfig.Title != "Home" {
+		t.Errorf("expected no namespace and the title Home, got %q and %q",
+			dashboard.Namespace, dashboard.Spec.HomerConfig.Title)
+	}
+}
+
+func TestImportDashboardUnsupportedKeys(t *testing.T) {
+	file := writeImportConfig(t, "title: \"Home\"\nunknownKey: true\n")
+	manifest, err := importDashboard(file, "home", "")
+	if err != nil {
+		t.Fatalf("expected the supported keys to be imported, got %v", err)
+	}
+	var dashboard homerv1alpha1.Dashboard
+	if err := yaml.UnmarshalStrict(manifest, &dashboard); err != nil {
+		t.Fatalf("expected the unsupported key to be left out: %v\n%s", err, manifest)
+	}
+	if dashboard.Spec.HomerConfig.Title != "Home" {
+		t.Errorf("expected the title Home, got %q", dashboard.Spec.HomerConfig.Title)
+	}
+}
+
+func TestImportDashboardMissingFile(t *testing.T) {
+	if _, err := importDashboard(filepath.Join(t.TempDir(), "missing.yml"), "home", ""); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
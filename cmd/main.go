@@ -17,9 +17,12 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -36,6 +39,7 @@ import (
 
 	homerv1alpha1 "github.com/rajsinghtech/homer-operator.git/api/v1alpha1"
 	"github.com/rajsinghtech/homer-operator.git/internal/controller"
+	homer "github.com/rajsinghtech/homer-operator.git/pkg/homer"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -52,11 +56,25 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "explain" {
+		os.Exit(runExplain(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		os.Exit(runImport(os.Args[2:]))
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var instanceName string
+	var maxConcurrentReconciles int
+	var defaultsConfigMap string
+	var clusterName string
+	var enableTraefik bool
+	var enableEndpointSlices bool
+	var discoveryDebounce time.Duration
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
@@ -66,6 +84,23 @@ func main() {
 		"If set the metrics endpoint is served securely")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 4,
+		"The number of Dashboards reconciled in parallel.")
+	flag.StringVar(&defaultsConfigMap, "defaults-configmap", "",
+		"If set, the namespace/name of a ConfigMap whose config.yml key holds the Homer config "+
+			"merged beneath every Dashboard. It is read once at startup.")
+	flag.DurationVar(&discoveryDebounce, "discovery-debounce", time.Second,
+		"How long discovered resources must stay unchanged before the Dashboards are reconciled, "+
+			"so a burst of changes results in one reconcile. Zero reconciles right away.")
+	flag.BoolVar(&enableTraefik, "enable-traefik", false,
+		"If set, Traefik IngressRoutes (traefik.io/v1alpha1) are discovered like Ingresses.")
+	flag.BoolVar(&enableEndpointSlices, "enable-endpointslices", false,
+		"If set, EndpointSlices are watched for the Dashboards setting spec.discoverEndpointSlices.")
+	flag.StringVar(&clusterName, "cluster-name", "",
+		"The name of the cluster, available to Dashboard title and subtitle templates as {{ .ClusterName }}.")
+	flag.StringVar(&instanceName, "instance-name", "",
+		"If set, only Dashboards labeled homer.rajsingh.info/instance=<instance-name> are reconciled, "+
+			"and the resources created for them carry the same label.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -74,6 +109,11 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if maxConcurrentReconciles < 1 {
+		setupLog.Error(fmt.Errorf("got %d", maxConcurrentReconciles), "--max-concurrent-reconciles must be at least 1")
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancelation and
@@ -122,18 +162,30 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = (&controller.DashboardReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Dashboard")
-		os.Exit(1)
+	var defaults *homer.HomerConfig
+	if defaultsConfigMap != "" {
+		defaults, err = controller.LoadDefaults(context.Background(), mgr.GetAPIReader(), defaultsConfigMap)
+		if err != nil {
+			setupLog.Error(err, "unable to load the default config")
+			os.Exit(1)
+		}
 	}
-	if err = (&controller.IngressReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Ingress")
+
+	reconciler := &controller.DashboardReconciler{
+		Client:                  mgr.GetClient(),
+		APIReader:               mgr.GetAPIReader(),
+		Scheme:                  mgr.GetScheme(),
+		Recorder:                mgr.GetEventRecorderFor("dashboard-controller"),
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		InstanceName:            instanceName,
+		EnableTraefik:           enableTraefik,
+		EnableEndpointSlices:    enableEndpointSlices,
+		DiscoveryDebounce:       discoveryDebounce,
+		ClusterName:             clusterName,
+		Defaults:                defaults,
+	}
+	if err = reconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Dashboard")
 		os.Exit(1)
 	}
 	//+kubebuilder:scaffold:builder
@@ -142,7 +194,8 @@ func main() {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+	readyz := reconciler.ReadyzCheck(mgr.GetCache().WaitForCacheSync, mgr.Elected())
+	if err := mgr.AddReadyzCheck("readyz", readyz); err != nil {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
@@ -18,28 +18,88 @@ package controller
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
 
 	homerv1alpha1 "github.com/rajsinghtech/homer-operator.git/api/v1alpha1"
 	homer "github.com/rajsinghtech/homer-operator.git/pkg/homer"
+	"github.com/rajsinghtech/homer-operator.git/pkg/utils"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // DashboardReconciler reconciles a Dashboard object
 type DashboardReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Recorder records Events on the Dashboard for what a reconcile did or why it failed.
+	Recorder record.EventRecorder
+	// MaxConcurrentReconciles is how many Dashboards are reconciled in parallel. Each
+	// reconcile builds its config locally, and the work queue never hands the same
+	// Dashboard to two workers, so reconciles share no state. Zero means one.
+	MaxConcurrentReconciles int
+	// InstanceName limits the reconciler to Dashboards carrying the homer.rajsingh.info/instance
+	// label with this value, and sets that label on every resource it creates. When empty,
+	// only Dashboards without the label are reconciled.
+	InstanceName string
+	// DiscoveryDebounce delays reconciles caused by changes to discovered resources until
+	// none changed for this long, so a burst of changes results in one reconcile per Dashboard.
+	DiscoveryDebounce time.Duration
+	// EnableTraefik adds items for Traefik IngressRoutes. The traefik.io CRDs must be installed.
+	EnableTraefik bool
+	// EnableEndpointSlices watches EndpointSlices for the Dashboards setting
+	// spec.discoverEndpointSlices. When false, that field has no effect.
+	EnableEndpointSlices bool
+	// ClusterName is the name of the cluster the operator runs in, available to the
+	// title and subtitle templates of every Dashboard as {{ .ClusterName }}.
+	ClusterName string
+	// Defaults is the operator-wide config merged beneath the config of every Dashboard,
+	// which wins field by field. See LoadDefaults.
+	Defaults *homer.HomerConfig
+	// APIReader reads the Secrets referenced in spec.secrets straight from the API server.
+	// Secrets are only watched by their metadata, so the manager does not cache the values
+	// of every Secret in the cluster. Nil reads through Client.
+	APIReader client.Reader
+	// reconciled is set once a reconcile completed, whether it failed or not, see ReadyzCheck.
+	reconciled atomic.Bool
 }
 
 //+kubebuilder:rbac:groups=homer.rajsingh.info,resources=dashboards,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=homer.rajsingh.info,resources=dashboards/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=homer.rajsingh.info,resources=dashboards/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -52,13 +112,14 @@ type DashboardReconciler struct {
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.17.0/pkg/reconcile
 func (r *DashboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
+	defer r.reconciled.Store(true)
 	var dashboard homerv1alpha1.Dashboard
 	if err := r.Get(ctx, req.NamespacedName, &dashboard); err != nil {
 		if client.IgnoreNotFound(err) != nil {
 			log.Error(err, "unable to fetch Dashboard", "dashboard", req.NamespacedName)
 			return ctrl.Result{}, client.IgnoreNotFound(err)
 		}
-		labelSelector := client.MatchingLabels{"dashboard.homer.rajsingh.info/name": req.NamespacedName.Name}
+		labelSelector := client.MatchingLabelsSelector{Selector: r.resourceSelector(req.NamespacedName.Name)}
 		// List of resources to delete
 		resourceTypes := []struct {
 			list     client.ObjectList
@@ -70,7 +131,7 @@ func (r *DashboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 
 		for _, resourceType := range resourceTypes {
-			if err := r.List(ctx, resourceType.list, labelSelector); err != nil {
+			if err := r.List(ctx, resourceType.list, labelSelector, client.InNamespace(req.Namespace)); err != nil {
 				log.Error(err, "unable to list resources", "dashboard", req.NamespacedName)
 				return ctrl.Result{}, err
 			}
@@ -85,18 +146,393 @@ func (r *DashboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 		return ctrl.Result{}, nil
 	}
-	ingresses := &networkingv1.IngressList{}
-	if err := r.List(ctx, ingresses); err != nil {
+	if !r.ownsInstance(&dashboard) {
+		return ctrl.Result{}, nil
+	}
+	if dashboard.Spec.Paused {
+		return ctrl.Result{}, r.setPausedCondition(ctx, &dashboard)
+	}
+	if err := r.validateDeploymentRef(ctx, &dashboard); err != nil {
+		log.Error(err, "invalid deploymentRef", "dashboard", req.NamespacedName)
+		r.Recorder.Event(&dashboard, corev1.EventTypeWarning, "InvalidDeploymentRef", err.Error())
+		if statusErr := r.updateStatus(ctx, &dashboard, err); statusErr != nil {
+			log.Error(statusErr, "unable to update Dashboard status", "dashboard", req.NamespacedName)
+		}
+		return ctrl.Result{}, err
+	}
+	ingressList, err := r.listCandidateIngresses(ctx, &dashboard)
+	if err != nil {
 		log.Error(err, "unable to list Ingresses", "dashboard", req.NamespacedName)
 		return ctrl.Result{}, err
 	}
+	// UpdateHomerConfig applies the filters itself; the debug entries record their reasons.
+	var debugEntries []homer.DebugEntry
+	if dashboard.Spec.Debug {
+		for _, ingress := range ingressList.Items {
+			debugEntries = append(debugEntries, homer.DebugEntry{
+				Kind: "ingress", Namespace: ingress.Namespace, Name: ingress.Name,
+				Reason: EvaluateIngress(&dashboard, &ingress).Reason(),
+			})
+		}
+	}
+	homerConfig, err := r.buildHomerConfig(ctx, &dashboard)
+	if err != nil {
+		log.Error(err, "unable to build Homer config", "dashboard", req.NamespacedName)
+		r.Recorder.Event(&dashboard, corev1.EventTypeWarning, "ConfigBuildFailed", err.Error())
+		if statusErr := r.updateStatus(ctx, &dashboard, err); statusErr != nil {
+			log.Error(statusErr, "unable to update Dashboard status", "dashboard", req.NamespacedName)
+		}
+		return ctrl.Result{}, err
+	}
 	// Resource Created - Create all resources
-	deployment := homer.CreateDeployment(dashboard.Name, dashboard.Namespace)
-	service := homer.CreateService(dashboard.Name, dashboard.Namespace)
-	configMap := homer.CreateConfigMap(dashboard.Spec.HomerConfig, dashboard.Name, dashboard.Namespace, *ingresses)
+	deployment := homer.CreateDeployment(dashboard.Name, dashboard.Namespace, r.InstanceName)
+	if dashboard.Spec.Deployment != nil {
+		mergeMetadata(&deployment.ObjectMeta, dashboard.Spec.Deployment.Annotations, dashboard.Spec.Deployment.Labels)
+	}
+	applyPodTemplate(&deployment, dashboard.Spec.PodTemplate)
+	applyExtraVolumes(&deployment, dashboard.Spec.ExtraVolumes, dashboard.Spec.ExtraVolumeMounts)
+	applyBaseURL(&deployment, dashboard.Spec.BaseURL)
+	if dashboard.Spec.Assets != nil {
+		homer.AddAssetsVolume(&deployment, dashboard.Name)
+	}
+	applyAssetsPath(&deployment, dashboard.Spec.HomerAssetsPath)
+	if dashboard.Spec.VerifyConfigServed {
+		applyConfigCheck(&deployment, dashboard.Spec.BaseURL, dashboard.Spec.ConfigKey)
+	}
+	pages, err := r.findPages(ctx, &dashboard)
+	if err != nil {
+		log.Error(err, "unable to list the Dashboards served as pages", "dashboard", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+	if len(pages) > 0 {
+		homer.AddPages(&deployment, pages)
+	}
+	service := homer.CreateService(dashboard.Name, dashboard.Namespace, r.InstanceName)
+	applyServiceConfig(&service, dashboard.Spec.Service)
+	options := DiscoveryOptions(&dashboard, log)
+	options.ClusterName = r.ClusterName
+	if err := homer.UpdateHomerConfig(&homerConfig, *ingressList, options); err != nil {
+		log.Error(err, "unable to add discovered items", "dashboard", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+	if err := r.addEndpointSlices(ctx, &dashboard, &homerConfig); err != nil {
+		log.Error(err, "unable to add EndpointSlice items", "dashboard", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+	if err := r.addIngressRoutes(ctx, &dashboard, &homerConfig, options); err != nil {
+		log.Error(err, "unable to add IngressRoute items", "dashboard", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+	homer.RestrictSmartCardTypes(&homerConfig, dashboard.Spec.AllowedSmartCardTypes, log)
+	// Render the config again when the visibility window of an item opens or closes.
+	requeueAfter := homer.ApplyVisibilityWindows(&homerConfig, time.Now())
+	homer.SortServiceItems(&homerConfig, dashboard.Spec.SortStrategy)
+	homer.OrderServiceItems(&homerConfig, log)
+	dashboard.Status.DroppedItems = homer.LimitServiceItems(&homerConfig, dashboard.Spec.MaxItemsPerService)
+	if dashboard.Status.DroppedItems > 0 {
+		log.Info("Dropped discovered items over maxItemsPerService", "dashboard", req.NamespacedName,
+			"dropped", dashboard.Status.DroppedItems, "maxItemsPerService", dashboard.Spec.MaxItemsPerService)
+	}
+	titleData := homer.NewTitleData(homerConfig, r.ClusterName, dashboard.Name, dashboard.Namespace)
+	if err := homer.RenderTitles(&homerConfig, titleData); err != nil {
+		log.Error(err, "unable to render the Homer config titles", "dashboard", req.NamespacedName)
+		r.Recorder.Event(&dashboard, corev1.EventTypeWarning, "ConfigBuildFailed", err.Error())
+		if statusErr := r.updateStatus(ctx, &dashboard, err); statusErr != nil {
+			log.Error(statusErr, "unable to update Dashboard status", "dashboard", req.NamespacedName)
+		}
+		return ctrl.Result{}, err
+	}
+	warnings := homer.ValidateHomerConfig(&homerConfig)
+	for _, warning := range warnings {
+		log.Info("Homer config warning", "dashboard", req.NamespacedName, "warning", warning)
+	}
+	setConfigValidCondition(&dashboard, warnings)
+	if len(warnings) > 0 {
+		r.Recorder.Event(&dashboard, corev1.EventTypeWarning, "ValidationWarnings", strings.Join(warnings, "; "))
+	}
+	configMap := homer.CreateConfigMap(homerConfig, dashboard.Name, dashboard.Namespace, r.InstanceName,
+		homer.RenderOptions{
+			KeyOverrides: dashboard.Spec.YAMLKeyOverrides,
+			Minimal:      dashboard.Spec.OutputStyle == homerv1alpha1.OutputStyleMinimal,
+			ConfigKey:    dashboard.Spec.ConfigKey,
+		})
+	if dashboard.Spec.ValidationLevel == homerv1alpha1.ValidationLevelStrict {
+		if err := validateConfigSchema(&configMap); err != nil {
+			log.Error(err, "rendered Homer config does not match the Homer schema", "dashboard", req.NamespacedName)
+			r.Recorder.Event(&dashboard, corev1.EventTypeWarning, "ConfigBuildFailed", err.Error())
+			if statusErr := r.updateStatus(ctx, &dashboard, err); statusErr != nil {
+				log.Error(statusErr, "unable to update Dashboard status", "dashboard", req.NamespacedName)
+			}
+			return ctrl.Result{}, err
+		}
+	}
+	existing := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(&configMap), existing); client.IgnoreNotFound(err) != nil {
+		log.Error(err, "unable to fetch the Homer ConfigMap", "dashboard", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+	manualOverride := existing.Annotations[homer.ManualOverrideAnnotation] == "true"
+	if manualOverride {
+		log.Info("Not regenerating the manually overridden Homer ConfigMap", "dashboard", req.NamespacedName,
+			"annotation", homer.ManualOverrideAnnotation)
+		configMap = *existing
+	}
+	setManualOverrideCondition(&dashboard, manualOverride)
+	if dashboard.Spec.RestartOnConfigChange {
+		checksum := configMap.Annotations[homer.ConfigChecksumAnnotation]
+		mergeMetadata(&deployment.Spec.Template.ObjectMeta,
+			map[string]string{homer.ConfigChecksumAnnotation: checksum}, nil)
+	}
 	// List of resources
 	resources := []client.Object{&deployment, &service, &configMap}
+	if dashboard.Spec.DeploymentRef != "" {
+		// The Deployment of the referenced Dashboard serves the config as a page.
+		resources = []client.Object{&configMap}
+		for _, obj := range []client.Object{&appsv1.Deployment{}, &corev1.Service{}} {
+			obj.SetName(dashboard.Name)
+			if err := r.deleteResource(ctx, &dashboard, obj); err != nil {
+				log.Error(err, "unable to delete the Homer workload", "dashboard", req.NamespacedName)
+				return ctrl.Result{}, err
+			}
+		}
+	}
+	if dashboard.Spec.EmitInventory {
+		inventory, err := homer.CreateInventoryConfigMap(homerConfig, dashboard.Name, dashboard.Namespace, r.InstanceName)
+		if err != nil {
+			log.Error(err, "unable to render the inventory", "dashboard", req.NamespacedName)
+			return ctrl.Result{}, err
+		}
+		resources = append(resources, &inventory)
+	} else if err := r.deleteConfigMap(ctx, &dashboard, homer.InventoryConfigMapName(dashboard.Name)); err != nil {
+		log.Error(err, "unable to delete the inventory", "dashboard", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+	if dashboard.Spec.Debug {
+		debug, err := homer.CreateDebugConfigMap(debugEntries, dashboard.Name, dashboard.Namespace, r.InstanceName)
+		if err != nil {
+			log.Error(err, "unable to render the debug report", "dashboard", req.NamespacedName)
+			return ctrl.Result{}, err
+		}
+		resources = append(resources, &debug)
+	} else if err := r.deleteConfigMap(ctx, &dashboard, homer.DebugConfigMapName(dashboard.Name)); err != nil {
+		log.Error(err, "unable to delete the debug report", "dashboard", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+	if dashboard.Spec.Assets != nil {
+		assets := homer.CreateAssetConfigMap(dashboard.Spec.Assets.Files, dashboard.Spec.Assets.Stylesheets,
+			dashboard.Name, dashboard.Namespace, r.InstanceName)
+		resources = append(resources, &assets)
+	} else if err := r.deleteConfigMap(ctx, &dashboard, homer.AssetConfigMapName(dashboard.Name)); err != nil {
+		log.Error(err, "unable to delete the assets", "dashboard", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
 
+	err = r.createOrUpdateResources(ctx, &dashboard, resources)
+	if statusErr := r.updateStatus(ctx, &dashboard, err); statusErr != nil {
+		log.Error(statusErr, "unable to update Dashboard status", "dashboard", req.NamespacedName)
+		if err == nil {
+			return ctrl.Result{}, statusErr
+		}
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// DiscoveryOptions derives the options for turning discovered resources into items from the Dashboard spec.
+func DiscoveryOptions(dashboard *homerv1alpha1.Dashboard, logger logr.Logger) homer.DiscoveryOptions {
+	serviceGroup := ""
+	if dashboard.Spec.ServiceGrouping == homerv1alpha1.ServiceGroupingNone {
+		serviceGroup = "Services"
+	}
+	return homer.DiscoveryOptions{
+		AllowedItemFields:     dashboard.Spec.AllowedItemFields,
+		AllowedServiceFields:  dashboard.Spec.AllowedServiceFields,
+		Strict:                dashboard.Spec.ValidationLevel == homerv1alpha1.ValidationLevelStrict,
+		DefaultScheme:         dashboard.Spec.DefaultScheme,
+		DomainFilters:         dashboard.Spec.DomainFilters,
+		ExcludeHostPatterns:   dashboard.Spec.ExcludeHostPatterns,
+		ExcludeNamePatterns:   dashboard.Spec.ExcludeNamePatterns,
+		IngressClassName:      dashboard.Spec.IngressClassName,
+		IncludeACMESolvers:    dashboard.Spec.IncludeACMESolvers,
+		ItemNameTemplate:      dashboard.Spec.ItemNameTemplate,
+		ServiceGroup:          serviceGroup,
+		IngressLogo:           dashboard.Spec.DefaultIngressLogo,
+		ServiceLogo:           dashboard.Spec.DefaultServiceLogo,
+		KeywordLabels:         dashboard.Spec.AutoKeywordsFromLabels,
+		PreferStatusHostnames: dashboard.Spec.PreferStatusHostnames,
+		Logger:                logger,
+	}
+}
+
+// buildHomerConfig returns the Dashboard's HomerConfig with the referenced theme
+// merged in and all secret references resolved. An empty title defaults to the
+// Dashboard's name, and an unset defaults.colorTheme to auto, following the system.
+func (r *DashboardReconciler) buildHomerConfig(ctx context.Context, dashboard *homerv1alpha1.Dashboard) (homer.HomerConfig, error) {
+	config := dashboard.Spec.HomerConfig
+	if err := r.applyThemeFrom(ctx, dashboard, &config); err != nil {
+		return config, err
+	}
+	if r.Defaults != nil {
+		config = homer.MergeHomerConfig(*r.Defaults, config)
+	}
+	if config.Title == "" {
+		config.Title = dashboard.Name
+	}
+	if config.Defaults.ColorTheme == "" {
+		config.Defaults.ColorTheme = "auto"
+	}
+	if err := homer.ValidateColors(config.Colors); err != nil {
+		return config, err
+	}
+	if dashboard.Spec.Assets != nil {
+		stylesheets, err := homer.StylesheetPaths(dashboard.Spec.Assets.Stylesheets, dashboard.Spec.Assets.Files)
+		if err != nil {
+			return config, err
+		}
+		config.Stylesheet = append(slices.Clip(config.Stylesheet), stylesheets...)
+	}
+	if err := r.resolveProxyHeaders(ctx, dashboard, &config); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+// LoadDefaults reads the operator-wide config from the config.yml key of the ConfigMap
+// named by ref, given as namespace/name.
+func LoadDefaults(ctx context.Context, reader client.Reader, ref string) (*homer.HomerConfig, error) {
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok || namespace == "" || name == "" {
+		return nil, fmt.Errorf("defaults configmap %q is not of the form namespace/name", ref)
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, configMap); err != nil {
+		return nil, fmt.Errorf("unable to fetch defaults configmap %s: %w", ref, err)
+	}
+	data, ok := configMap.Data["config.yml"]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in defaults configmap %s", "config.yml", ref)
+	}
+	config, err := homer.LoadHomerConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse defaults configmap %s: %w", ref, err)
+	}
+	return &config, nil
+}
+
+// applyThemeFrom loads the theme ConfigMap referenced by the Dashboard, if any, and
+// merges it beneath the theme and colors set inline.
+func (r *DashboardReconciler) applyThemeFrom(ctx context.Context, dashboard *homerv1alpha1.Dashboard, config *homer.HomerConfig) error {
+	if dashboard.Spec.ThemeFrom == nil {
+		return nil
+	}
+	ref := dashboard.Spec.ThemeFrom.ConfigMapRef
+	key := ref.Key
+	if key == "" {
+		key = "theme.yml"
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: dashboard.Namespace, Name: ref.Name}, configMap); err != nil {
+		return fmt.Errorf("unable to fetch theme configmap %s/%s: %w", dashboard.Namespace, ref.Name, err)
+	}
+	data, ok := configMap.Data[key]
+	if !ok {
+		return fmt.Errorf("key %q not found in theme configmap %s/%s", key, dashboard.Namespace, ref.Name)
+	}
+	theme, err := homer.LoadThemeConfig(data)
+	if err != nil {
+		return fmt.Errorf("unable to parse theme configmap %s/%s: %w", dashboard.Namespace, ref.Name, err)
+	}
+	if config.Theme == "" {
+		config.Theme = theme.Theme
+	}
+	config.Colors = homer.MergeColors(theme.Colors, config.Colors)
+	return nil
+}
+
+// resolveProxyHeaders sets the proxy headers referenced in spec.secrets on the config.
+// With the Ignore failure policy, headers that cannot be resolved are left out and
+// recorded in the Dashboard's status.secretErrors.
+func (r *DashboardReconciler) resolveProxyHeaders(ctx context.Context, dashboard *homerv1alpha1.Dashboard, config *homer.HomerConfig) error {
+	dashboard.Status.SecretErrors = nil
+	if len(dashboard.Spec.Secrets.ProxyHeaders) == 0 {
+		return nil
+	}
+	proxy := homer.ProxyConfig{Headers: map[string]string{}}
+	if config.Proxy != nil {
+		proxy.UseCredentials = config.Proxy.UseCredentials
+		for header, value := range config.Proxy.Headers {
+			proxy.Headers[header] = value
+		}
+	}
+	for header, ref := range dashboard.Spec.Secrets.ProxyHeaders {
+		value, err := r.resolveSecretValue(ctx, dashboard.Namespace, ref)
+		if err != nil && dashboard.Spec.Secrets.Optional && isSecretMissing(err) {
+			log.FromContext(ctx).Info("Skipping proxy header of missing optional secret", "header", header, "error", err.Error())
+			continue
+		}
+		if err != nil {
+			err = fmt.Errorf("unable to resolve proxy header %q: %w", header, err)
+			if dashboard.Spec.Secrets.FailurePolicy != homerv1alpha1.SecretFailurePolicyIgnore {
+				return err
+			}
+			dashboard.Status.SecretErrors = append(dashboard.Status.SecretErrors, err.Error())
+			continue
+		}
+		if value != "" {
+			proxy.Headers[header] = value
+		}
+	}
+	sort.Strings(dashboard.Status.SecretErrors)
+	config.Proxy = &proxy
+	return nil
+}
+
+// resolveSecretValue reads the value of a key from a Secret in the given namespace.
+func (r *DashboardReconciler) resolveSecretValue(ctx context.Context, namespace string, ref homerv1alpha1.SecretKeyRef) (string, error) {
+	var reader client.Reader = r.Client
+	if r.APIReader != nil {
+		reader = r.APIReader
+	}
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+		return "", fmt.Errorf("unable to fetch secret %s/%s: %w", namespace, ref.Name, err)
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("%w: key %q in secret %s/%s", errSecretKeyNotFound, ref.Key, namespace, ref.Name)
+	}
+	if ref.Decode == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(value)))
+		if err != nil {
+			return "", fmt.Errorf("unable to decode key %q in secret %s/%s: %w", ref.Key, namespace, ref.Name, err)
+		}
+		value = decoded
+	}
+	if ref.ValueFrom != "" {
+		extracted, err := utils.LookupJSONPath(value, ref.ValueFrom)
+		if err != nil {
+			return "", fmt.Errorf("unable to extract %s from key %q in secret %s/%s: %w",
+				ref.ValueFrom, ref.Key, namespace, ref.Name, err)
+		}
+		return extracted, nil
+	}
+	return string(value), nil
+}
+
+// errSecretKeyNotFound is returned when a referenced Secret lacks the referenced key.
+var errSecretKeyNotFound = errors.New("secret key not found")
+
+// isSecretMissing reports whether err means the referenced Secret or key does not exist.
+func isSecretMissing(err error) bool {
+	return apierrors.IsNotFound(err) || errors.Is(err, errSecretKeyNotFound)
+}
+
+// createOrUpdateResources creates each resource, or updates it if it already exists,
+// and records an Event on the Dashboard for resources created and configs changed.
+func (r *DashboardReconciler) createOrUpdateResources(ctx context.Context, dashboard *homerv1alpha1.Dashboard, resources []client.Object) error {
+	log := log.FromContext(ctx)
 	for _, resource := range resources {
 		newResource := reflect.New(reflect.TypeOf(resource).Elem()).Interface().(client.Object)
 		err := r.Get(ctx, client.ObjectKey{Namespace: resource.GetNamespace(), Name: resource.GetName()}, newResource)
@@ -105,27 +541,471 @@ func (r *DashboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			err = r.Create(ctx, resource)
 			if err != nil {
 				log.Error(err, "unable to create resource", "resource", resource)
-				return ctrl.Result{}, err
+				r.Recorder.Eventf(dashboard, corev1.EventTypeWarning, "CreateFailed", "Unable to create %s %s: %v",
+					reflect.TypeOf(resource).Elem().Name(), resource.GetName(), err)
+				return err
 			}
 			log.Info("Resource created", "resource", resource)
+			r.Recorder.Eventf(dashboard, corev1.EventTypeNormal, "Created", "Created %s %s",
+				reflect.TypeOf(resource).Elem().Name(), resource.GetName())
 		case client.IgnoreNotFound(err) != nil:
 			log.Error(err, "unable to fetch resource", "resource", resource)
-			return ctrl.Result{}, err
+			return err
 		default:
 			err = r.Update(ctx, resource)
 			if err != nil {
 				log.Error(err, "unable to update resource", "resource", resource)
-				return ctrl.Result{}, err
+				r.Recorder.Eventf(dashboard, corev1.EventTypeWarning, "UpdateFailed", "Unable to update %s %s: %v",
+					reflect.TypeOf(resource).Elem().Name(), resource.GetName(), err)
+				return err
 			}
 			log.Info("Resource updated", "resource", resource)
+			if configMap, ok := resource.(*corev1.ConfigMap); ok && configMap.Name == dashboard.Name &&
+				!reflect.DeepEqual(configMap.Data, newResource.(*corev1.ConfigMap).Data) {
+				r.Recorder.Event(dashboard, corev1.EventTypeNormal, "ConfigUpdated", "Homer config regenerated")
+				key := dashboard.Spec.ConfigKey
+				if key == "" {
+					key = homer.DefaultConfigKey
+				}
+				changes := homer.DiffConfigYAML(newResource.(*corev1.ConfigMap).Data[key], configMap.Data[key])
+				log.V(1).Info("Homer config changed", "configmap", client.ObjectKeyFromObject(configMap), "changes", changes)
+			}
 		}
 	}
-	return ctrl.Result{}, nil
+	return nil
+}
+
+// deleteConfigMap deletes a ConfigMap of the Dashboard that is no longer wanted, such as
+// the inventory once spec.emitInventory is turned off. A ConfigMap of that name not
+// created for the Dashboard is left alone.
+func (r *DashboardReconciler) deleteConfigMap(ctx context.Context, dashboard *homerv1alpha1.Dashboard, name string) error {
+	configMap := &corev1.ConfigMap{}
+	configMap.SetName(name)
+	return r.deleteResource(ctx, dashboard, configMap)
+}
+
+// deleteResource deletes the resource of obj's kind and name in the Dashboard's namespace
+// if it was created for the Dashboard.
+func (r *DashboardReconciler) deleteResource(ctx context.Context, dashboard *homerv1alpha1.Dashboard, obj client.Object) error {
+	if err := r.Get(ctx, client.ObjectKey{Namespace: dashboard.Namespace, Name: obj.GetName()}, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !r.resourceSelector(dashboard.Name).Matches(labels.Set(obj.GetLabels())) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, obj))
+}
+
+// validateDeploymentRef rejects a spec.deploymentRef naming the Dashboard itself, whose
+// Deployment it would delete, or a Dashboard that has a deploymentRef of its own and so
+// no Deployment to serve pages. A Dashboard that does not exist yet is accepted.
+func (r *DashboardReconciler) validateDeploymentRef(ctx context.Context, dashboard *homerv1alpha1.Dashboard) error {
+	ref := dashboard.Spec.DeploymentRef
+	if ref == "" {
+		return nil
+	}
+	if ref == dashboard.Name {
+		return fmt.Errorf("deploymentRef %q names the Dashboard itself", ref)
+	}
+	referenced := &homerv1alpha1.Dashboard{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: dashboard.Namespace, Name: ref}, referenced)
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("unable to fetch the Dashboard named in deploymentRef: %w", err)
+	}
+	if err == nil && referenced.Spec.DeploymentRef != "" {
+		return fmt.Errorf("deploymentRef %q names a Dashboard with a deploymentRef of its own", ref)
+	}
+	return nil
+}
+
+// findPages returns the config keys, by ConfigMap name, of the Dashboards naming dashboard
+// in spec.deploymentRef, which its Deployment serves as additional pages.
+func (r *DashboardReconciler) findPages(ctx context.Context, dashboard *homerv1alpha1.Dashboard) (map[string]string, error) {
+	var dashboardList homerv1alpha1.DashboardList
+	if err := r.List(ctx, &dashboardList, client.InNamespace(dashboard.Namespace)); err != nil {
+		return nil, err
+	}
+	pages := map[string]string{}
+	for _, page := range dashboardList.Items {
+		if page.Spec.DeploymentRef != dashboard.Name || page.Name == dashboard.Name || !r.ownsInstance(&page) {
+			continue
+		}
+		pages[page.Name] = page.Spec.ConfigKey
+		if pages[page.Name] == "" {
+			pages[page.Name] = homer.DefaultConfigKey
+		}
+	}
+	return pages, nil
+}
+
+// applyServiceConfig sets the fields of config that are set on the Homer Service.
+func applyServiceConfig(service *corev1.Service, config *homerv1alpha1.ServiceConfig) {
+	if config == nil {
+		return
+	}
+	mergeMetadata(&service.ObjectMeta, config.Annotations, nil)
+	if config.Type != "" {
+		service.Spec.Type = config.Type
+	}
+	service.Spec.IPFamilyPolicy = config.IPFamilyPolicy
+	service.Spec.IPFamilies = config.IPFamilies
+}
+
+// mergeMetadata adds annotations and labels to meta. Labels already set by the
+// operator, which its selectors and cleanup rely on, are kept.
+func mergeMetadata(meta *metav1.ObjectMeta, annotations, labels map[string]string) {
+	if len(annotations) > 0 && meta.Annotations == nil {
+		meta.Annotations = make(map[string]string, len(annotations))
+	}
+	for key, value := range annotations {
+		meta.Annotations[key] = value
+	}
+	if len(labels) > 0 && meta.Labels == nil {
+		meta.Labels = make(map[string]string, len(labels))
+	}
+	for key, value := range labels {
+		if _, ok := meta.Labels[key]; !ok {
+			meta.Labels[key] = value
+		}
+	}
+}
+
+// applyPodTemplate sets the fields of template that are set on the pod of the Homer Deployment.
+func applyPodTemplate(deployment *appsv1.Deployment, template *homerv1alpha1.PodTemplate) {
+	if template == nil {
+		return
+	}
+	pod := &deployment.Spec.Template.Spec
+	if template.SecurityContext != nil {
+		pod.SecurityContext = template.SecurityContext
+	}
+	if template.ContainerSecurityContext != nil {
+		for i := range pod.Containers {
+			pod.Containers[i].SecurityContext = template.ContainerSecurityContext
+		}
+	}
+	if len(template.NodeSelector) > 0 {
+		pod.NodeSelector = template.NodeSelector
+	}
+	if len(template.Tolerations) > 0 {
+		pod.Tolerations = template.Tolerations
+	}
+	if template.Affinity != nil {
+		pod.Affinity = template.Affinity
+	}
+	if template.PriorityClassName != "" {
+		pod.PriorityClassName = template.PriorityClassName
+	}
+}
+
+// applyExtraVolumes appends the extra volumes to the Homer pod and the extra mounts to its containers.
+func applyExtraVolumes(deployment *appsv1.Deployment, volumes []corev1.Volume, mounts []corev1.VolumeMount) {
+	pod := &deployment.Spec.Template.Spec
+	pod.Volumes = append(pod.Volumes, volumes...)
+	for i := range pod.Containers {
+		pod.Containers[i].VolumeMounts = append(pod.Containers[i].VolumeMounts, mounts...)
+	}
+}
+
+// applyBaseURL serves Homer under the path baseURL by setting the SUBFOLDER variable of
+// the Homer container. The root path needs no variable.
+func applyBaseURL(deployment *appsv1.Deployment, baseURL string) {
+	subfolder := strings.TrimSuffix(baseURL, "/")
+	if subfolder == "" {
+		return
+	}
+	container := &deployment.Spec.Template.Spec.Containers[0]
+	container.Env = append(container.Env, corev1.EnvVar{Name: "SUBFOLDER", Value: subfolder})
+}
+
+// applyAssetsPath mounts the config and the assets of the Homer container under path, for
+// Homer images that do not serve their assets from /www/assets.
+func applyAssetsPath(deployment *appsv1.Deployment, path string) {
+	path = strings.TrimSuffix(path, "/")
+	if path == "" {
+		return
+	}
+	container := &deployment.Spec.Template.Spec.Containers[0]
+	for i := range container.VolumeMounts {
+		switch container.VolumeMounts[i].Name {
+		case "config-volume":
+			container.VolumeMounts[i].MountPath = path
+		case "assets-volume":
+			container.VolumeMounts[i].MountPath = path + "/custom"
+		}
+	}
+}
+
+// applyConfigCheck adds a startup probe to the Homer container that fetches the config
+// the way the Homer frontend does, which fails when the image serves its assets from
+// another directory than the config is mounted in.
+func applyConfigCheck(deployment *appsv1.Deployment, baseURL, configKey string) {
+	if configKey == "" {
+		configKey = homer.DefaultConfigKey
+	}
+	container := &deployment.Spec.Template.Spec.Containers[0]
+	container.StartupProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: strings.TrimSuffix(baseURL, "/") + "/assets/" + configKey,
+				Port: intstr.FromInt32(container.Ports[0].ContainerPort),
+			},
+		},
+		PeriodSeconds:    5,
+		FailureThreshold: 12,
+	}
+}
+
+// validateConfigSchema checks the config rendered into configMap against the Homer config
+// schema, so a key or value Homer would silently ignore fails the reconcile.
+func validateConfigSchema(configMap *corev1.ConfigMap) error {
+	for key, config := range configMap.Data {
+		problems, err := homer.ValidateHomerSchema([]byte(config))
+		if err != nil {
+			return err
+		}
+		if len(problems) > 0 {
+			return fmt.Errorf("%s does not match the Homer config schema: %s", key, strings.Join(problems, "; "))
+		}
+	}
+	return nil
+}
+
+// setManualOverrideCondition reports on the Dashboard that its ConfigMap is hand-edited
+// and left alone, and clears the report once the override is removed.
+func setManualOverrideCondition(dashboard *homerv1alpha1.Dashboard, manualOverride bool) {
+	if !manualOverride {
+		meta.RemoveStatusCondition(&dashboard.Status.Conditions, homerv1alpha1.ConditionManualOverride)
+		return
+	}
+	meta.SetStatusCondition(&dashboard.Status.Conditions, metav1.Condition{
+		Type:               homerv1alpha1.ConditionManualOverride,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: dashboard.Generation,
+		Reason:             "ManualOverride",
+		Message:            "The Homer ConfigMap is not regenerated while it has the " + homer.ManualOverrideAnnotation + " annotation",
+	})
+}
+
+// setConfigValidCondition records the validation warnings of the rendered config on the Dashboard.
+func setConfigValidCondition(dashboard *homerv1alpha1.Dashboard, warnings []string) {
+	condition := metav1.Condition{
+		Type:               homerv1alpha1.ConditionConfigValid,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: dashboard.Generation,
+		Reason:             "Valid",
+		Message:            "Homer config has no warnings",
+	}
+	if len(warnings) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ValidationWarnings"
+		condition.Message = strings.Join(warnings, "; ")
+	}
+	meta.SetStatusCondition(&dashboard.Status.Conditions, condition)
+}
+
+// setPausedCondition records that reconciliation of the Dashboard is paused.
+func (r *DashboardReconciler) setPausedCondition(ctx context.Context, dashboard *homerv1alpha1.Dashboard) error {
+	changed := meta.SetStatusCondition(&dashboard.Status.Conditions, metav1.Condition{
+		Type:               homerv1alpha1.ConditionPaused,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: dashboard.Generation,
+		Reason:             "Paused",
+		Message:            "Reconciliation is paused by spec.paused",
+	})
+	if !changed {
+		return nil
+	}
+	return r.Status().Update(ctx, dashboard)
+}
+
+// updateStatus records the outcome of a reconcile as conditions on the Dashboard.
+func (r *DashboardReconciler) updateStatus(ctx context.Context, dashboard *homerv1alpha1.Dashboard, reconcileErr error) error {
+	rendered := metav1.Condition{
+		Type:               homerv1alpha1.ConditionConfigRendered,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: dashboard.Generation,
+		Reason:             "Rendered",
+		Message:            "Homer config rendered and applied",
+	}
+	if reconcileErr != nil {
+		rendered.Status = metav1.ConditionFalse
+		rendered.Reason = "ReconcileFailed"
+		rendered.Message = reconcileErr.Error()
+	}
+	meta.SetStatusCondition(&dashboard.Status.Conditions, rendered)
+
+	available := metav1.Condition{
+		Type:               homerv1alpha1.ConditionDeploymentAvailable,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: dashboard.Generation,
+		Reason:             "DeploymentUnavailable",
+		Message:            "Homer Deployment has no available replicas",
+	}
+	deploymentName := dashboard.Name
+	if dashboard.Spec.DeploymentRef != "" {
+		deploymentName = dashboard.Spec.DeploymentRef
+	}
+	deployment := &appsv1.Deployment{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: dashboard.Namespace, Name: deploymentName}, deployment)
+	switch {
+	case client.IgnoreNotFound(err) != nil:
+		return err
+	case err != nil:
+		available.Reason = "DeploymentNotFound"
+		available.Message = "Homer Deployment does not exist"
+	default:
+		for _, condition := range deployment.Status.Conditions {
+			if condition.Type == appsv1.DeploymentAvailable && condition.Status == corev1.ConditionTrue {
+				available.Status = metav1.ConditionTrue
+				available.Reason = "DeploymentAvailable"
+				available.Message = "Homer Deployment is available"
+			}
+		}
+	}
+	meta.SetStatusCondition(&dashboard.Status.Conditions, available)
+
+	ready := metav1.Condition{
+		Type:               homerv1alpha1.ConditionReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: dashboard.Generation,
+		Reason:             rendered.Reason,
+		Message:            rendered.Message,
+	}
+	switch {
+	case rendered.Status != metav1.ConditionTrue:
+	case available.Status != metav1.ConditionTrue:
+		ready.Reason = available.Reason
+		ready.Message = available.Message
+	default:
+		ready.Status = metav1.ConditionTrue
+		ready.Reason = "Ready"
+		ready.Message = "Dashboard is ready"
+	}
+	meta.SetStatusCondition(&dashboard.Status.Conditions, ready)
+	meta.RemoveStatusCondition(&dashboard.Status.Conditions, homerv1alpha1.ConditionPaused)
+
+	return r.Status().Update(ctx, dashboard)
+}
+
+// controllerOptions returns the options of the Dashboard controller.
+func (r *DashboardReconciler) controllerOptions() controller.Options {
+	return controller.Options{MaxConcurrentReconciles: max(r.MaxConcurrentReconciles, 1)}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DashboardReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&homerv1alpha1.Dashboard{}).
-		Complete(r)
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &networkingv1.Ingress{},
+		ingressClassIndex, indexIngressClass); err != nil {
+		return err
+	}
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&homerv1alpha1.Dashboard{}, builder.WithPredicates(predicate.NewPredicateFuncs(r.ownsInstance))).
+		WithOptions(r.controllerOptions()).
+		Watches(&appsv1.Deployment{}, handler.EnqueueRequestsFromMapFunc(dashboardForResource),
+			builder.WithPredicates(predicate.NewPredicateFuncs(r.ownsInstance))).
+		Watches(&networkingv1.Ingress{}, debouncedEnqueue(r.DiscoveryDebounce, r.findDashboardsForIngress),
+			builder.WithPredicates(ingressItemsChanged)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.findDashboardsForThemeConfigMap)).
+		// Removing the manual override annotation regenerates the ConfigMap right away.
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(dashboardForResource),
+			builder.WithPredicates(predicate.NewPredicateFuncs(r.ownsInstance), predicate.AnnotationChangedPredicate{})).
+		Watches(&homerv1alpha1.Dashboard{}, handler.EnqueueRequestsFromMapFunc(referencedDashboard)).
+		// Only the metadata of Secrets is cached; their values are read through APIReader.
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.findDashboardsForSecret),
+			builder.OnlyMetadata)
+	if r.EnableEndpointSlices {
+		b = b.Watches(&discoveryv1.EndpointSlice{},
+			debouncedEnqueue(r.DiscoveryDebounce, r.findDashboardsForEndpointSlice))
+	}
+	if r.EnableTraefik {
+		// IngressRoutes are mapped like ingresses, to the Dashboards that may discover them.
+		b = b.Watches(newIngressRoute(), debouncedEnqueue(r.DiscoveryDebounce, r.findDashboardsForIngress),
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{},
+				predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{})))
+	}
+	return b.Complete(r)
+}
+
+// ownsInstance reports whether obj belongs to this reconciler's operator instance.
+func (r *DashboardReconciler) ownsInstance(obj client.Object) bool {
+	return obj.GetLabels()[homer.InstanceLabel] == r.InstanceName
+}
+
+// resourceSelector selects the resources this operator instance created for the named Dashboard.
+func (r *DashboardReconciler) resourceSelector(name string) labels.Selector {
+	selector := labels.SelectorFromSet(labels.Set{"dashboard.homer.rajsingh.info/name": name})
+	operator, values := selection.Equals, []string{r.InstanceName}
+	if r.InstanceName == "" {
+		operator, values = selection.DoesNotExist, nil
+	}
+	requirement, err := labels.NewRequirement(homer.InstanceLabel, operator, values)
+	if err != nil {
+		return selector
+	}
+	return selector.Add(*requirement)
+}
+
+// findDashboardsForSecret maps a Secret to the Dashboards of this instance referencing it
+// in spec.secrets, so new and rotated values are picked up.
+func (r *DashboardReconciler) findDashboardsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	var dashboardList homerv1alpha1.DashboardList
+	if err := r.List(ctx, &dashboardList, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list Dashboards", "secret", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, dashboard := range dashboardList.Items {
+		if dashboard.Spec.Paused || !r.ownsInstance(&dashboard) {
+			continue
+		}
+		for _, ref := range dashboard.Spec.Secrets.ProxyHeaders {
+			if ref.Name == obj.GetName() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: dashboard.Namespace, Name: dashboard.Name},
+				})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// findDashboardsForThemeConfigMap maps a ConfigMap to the Dashboards loading their theme from it.
+func (r *DashboardReconciler) findDashboardsForThemeConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	var dashboardList homerv1alpha1.DashboardList
+	if err := r.List(ctx, &dashboardList, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list Dashboards", "configmap", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, dashboard := range dashboardList.Items {
+		if dashboard.Spec.ThemeFrom != nil && dashboard.Spec.ThemeFrom.ConfigMapRef.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: dashboard.Namespace, Name: dashboard.Name},
+			})
+		}
+	}
+	return requests
+}
+
+// referencedDashboard maps a Dashboard to the Dashboard serving it as a page, so adding or
+// removing a page updates the serving Deployment.
+func referencedDashboard(ctx context.Context, obj client.Object) []reconcile.Request {
+	dashboard, ok := obj.(*homerv1alpha1.Dashboard)
+	if !ok || dashboard.Spec.DeploymentRef == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: dashboard.Namespace, Name: dashboard.Spec.DeploymentRef,
+	}}}
+}
+
+// dashboardForResource maps a managed resource back to the Dashboard that owns it.
+func dashboardForResource(ctx context.Context, obj client.Object) []reconcile.Request {
+	name, ok := obj.GetLabels()["dashboard.homer.rajsingh.info/name"]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}}}
 }
@@ -18,18 +18,53 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	homerv1alpha1 "github.com/rajsinghtech/homer-operator.git/api/v1alpha1"
+	homer "github.com/rajsinghtech/homer-operator.git/pkg/homer"
 )
 
+// newFakeReconciler returns a DashboardReconciler backed by a fake client seeded with objs.
+func newFakeReconciler(objs ...client.Object) *DashboardReconciler {
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(homerv1alpha1.AddToScheme(scheme)).To(Succeed())
+	scheme.AddKnownTypeWithName(ingressRouteGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(ingressRouteGVK.GroupVersion().WithKind("IngressRouteList"), &unstructured.UnstructuredList{})
+	return &DashboardReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&homerv1alpha1.Dashboard{}).
+			WithIndex(&networkingv1.Ingress{}, ingressClassIndex, indexIngressClass).
+			Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(100),
+	}
+}
+
 var _ = Describe("Dashboard Controller", func() {
 	Context("When reconciling a resource", func() {
 		const resourceName = "test-resource"
@@ -69,8 +104,9 @@ var _ = Describe("Dashboard Controller", func() {
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
 			controllerReconciler := &DashboardReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
 			}
 
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -81,4 +117,1014 @@ var _ = Describe("Dashboard Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+
+	Context("When building the Homer config", func() {
+		ctx := context.Background()
+
+		dashboard := &homerv1alpha1.Dashboard{
+			ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+			Spec: homerv1alpha1.DashboardSpec{
+				Secrets: homerv1alpha1.SecretsConfig{
+					ProxyHeaders: map[string]homerv1alpha1.SecretKeyRef{
+						"Authorization": {Name: "proxy-auth", Key: "token"},
+					},
+				},
+			},
+		}
+
+		It("should inject proxy headers resolved from secrets", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "proxy-auth", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("Bearer abc")},
+			}
+			config, err := newFakeReconciler(secret).buildHomerConfig(ctx, dashboard)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Proxy).NotTo(BeNil())
+			Expect(config.Proxy.Headers).To(HaveKeyWithValue("Authorization", "Bearer abc"))
+			Expect(dashboard.Spec.HomerConfig.Proxy).To(BeNil())
+		})
+
+		It("should decode and extract secret values when asked to", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "proxy-auth", Namespace: "default"},
+				Data: map[string][]byte{
+					"token":  []byte("QmVhcmVyIGFiYw=="),
+					"config": []byte(`{"auth":{"token":"Bearer xyz"}}`),
+				},
+			}
+			extracting := dashboard.DeepCopy()
+			extracting.Spec.Secrets.ProxyHeaders = map[string]homerv1alpha1.SecretKeyRef{
+				"Authorization": {Name: "proxy-auth", Key: "token", Decode: "base64"},
+				"X-Token":       {Name: "proxy-auth", Key: "config", ValueFrom: "$.auth.token"},
+			}
+			config, err := newFakeReconciler(secret).buildHomerConfig(ctx, extracting)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Proxy.Headers).To(HaveKeyWithValue("Authorization", "Bearer abc"))
+			Expect(config.Proxy.Headers).To(HaveKeyWithValue("X-Token", "Bearer xyz"))
+
+			extracting.Spec.Secrets.ProxyHeaders["X-Token"] = homerv1alpha1.SecretKeyRef{
+				Name: "proxy-auth", Key: "config", ValueFrom: "auth.password",
+			}
+			_, err = newFakeReconciler(secret).buildHomerConfig(ctx, extracting)
+			Expect(err).To(MatchError(ContainSubstring("auth.password")))
+		})
+
+		It("should fail when the referenced secret is missing", func() {
+			_, err := newFakeReconciler().buildHomerConfig(ctx, dashboard)
+			Expect(err).To(MatchError(ContainSubstring("proxy-auth")))
+		})
+
+		It("should skip missing optional secrets", func() {
+			optional := dashboard.DeepCopy()
+			optional.Spec.Secrets.Optional = true
+			config, err := newFakeReconciler().buildHomerConfig(ctx, optional)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Proxy.Headers).NotTo(HaveKey("Authorization"))
+		})
+
+		It("should read secrets through the API reader and read them again once they change", func() {
+			optional := dashboard.DeepCopy()
+			optional.Spec.Secrets.Optional = true
+			unrelated := dashboard.DeepCopy()
+			unrelated.Name = "unrelated"
+			unrelated.Spec.Secrets.ProxyHeaders = nil
+			reconciler := newFakeReconciler(optional, unrelated)
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(optional)}
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "proxy-auth", Namespace: "default"}}
+			Expect(reconciler.findDashboardsForSecret(ctx, secret)).To(ConsistOf(request))
+
+			reconciler.APIReader = fake.NewClientBuilder().WithObjects(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "proxy-auth", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("Bearer abc")},
+			}).Build()
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			configMap := &corev1.ConfigMap{}
+			Expect(reconciler.Get(ctx, request.NamespacedName, configMap)).To(Succeed())
+			Expect(configMap.Data["config.yml"]).To(ContainSubstring("Authorization: Bearer abc"))
+		})
+
+		It("should record unresolved secrets with the Ignore failure policy", func() {
+			ignoring := dashboard.DeepCopy()
+			ignoring.Spec.Secrets.FailurePolicy = homerv1alpha1.SecretFailurePolicyIgnore
+			config, err := newFakeReconciler().buildHomerConfig(ctx, ignoring)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Proxy.Headers).NotTo(HaveKey("Authorization"))
+			Expect(ignoring.Status.SecretErrors).To(ConsistOf(ContainSubstring("proxy-auth")))
+		})
+
+		It("should merge the referenced theme beneath inline colors", func() {
+			themed := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec: homerv1alpha1.DashboardSpec{
+					HomerConfig: homer.HomerConfig{
+						Colors: &homer.ColorConfig{Light: &homer.ThemeColors{Background: "#000000"}},
+					},
+					ThemeFrom: &homerv1alpha1.ThemeSource{
+						ConfigMapRef: homerv1alpha1.ConfigMap{Name: "palette"},
+					},
+				},
+			}
+			palette := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "palette", Namespace: "default"},
+				Data: map[string]string{"theme.yml": `theme: walkxcode
+colors:
+  light:
+    background: "#ffffff"
+    text: "#363636"
+`},
+			}
+			config, err := newFakeReconciler(palette).buildHomerConfig(ctx, themed)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Theme).To(Equal("walkxcode"))
+			Expect(config.Colors.Light.Background).To(Equal("#000000"))
+			Expect(config.Colors.Light.Text).To(Equal("#363636"))
+		})
+
+		It("should default an empty title to the Dashboard name", func() {
+			untitled := &homerv1alpha1.Dashboard{ObjectMeta: metav1.ObjectMeta{Name: "quick-start", Namespace: "default"}}
+			config, err := newFakeReconciler().buildHomerConfig(ctx, untitled)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Title).To(Equal("quick-start"))
+		})
+
+		It("should default an unset color theme to auto", func() {
+			dashboard := &homerv1alpha1.Dashboard{ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"}}
+			config, err := newFakeReconciler().buildHomerConfig(ctx, dashboard)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Defaults.ColorTheme).To(Equal("auto"))
+		})
+
+		It("should reject invalid colors", func() {
+			invalid := &homerv1alpha1.Dashboard{
+				Spec: homerv1alpha1.DashboardSpec{
+					HomerConfig: homer.HomerConfig{
+						Colors: &homer.ColorConfig{Dark: &homer.ThemeColors{Text: "#zzz"}},
+					},
+				},
+			}
+			_, err := newFakeReconciler().buildHomerConfig(ctx, invalid)
+			Expect(err).To(MatchError(ContainSubstring("colors.dark.text")))
+		})
+
+		It("should merge the operator defaults beneath the Dashboard config", func() {
+			defaultsConfigMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "homer-defaults", Namespace: "homer-system"},
+				Data: map[string]string{"config.yml": `footer: Operated by the platform team
+defaults:
+  layout: list
+  colorTheme: dark
+`},
+			}
+			reconciler := newFakeReconciler(defaultsConfigMap)
+			defaults, err := LoadDefaults(ctx, reconciler.Client, "homer-system/homer-defaults")
+			Expect(err).NotTo(HaveOccurred())
+			reconciler.Defaults = defaults
+			team := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec: homerv1alpha1.DashboardSpec{
+					HomerConfig: homer.HomerConfig{Defaults: homer.DefaultConfig{ColorTheme: "light"}},
+				},
+			}
+			config, err := reconciler.buildHomerConfig(ctx, team)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Footer).To(Equal("Operated by the platform team"))
+			Expect(config.Defaults).To(Equal(homer.DefaultConfig{Layout: "list", ColorTheme: "light"}))
+
+			_, err = LoadDefaults(ctx, reconciler.Client, "homer-defaults")
+			Expect(err).To(MatchError(ContainSubstring("namespace/name")))
+		})
+	})
+
+	Context("When an ingress stops matching a Dashboard", func() {
+		ctx := context.Background()
+
+		It("should drop the ingress from the rebuilt config", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec:       homerv1alpha1.DashboardSpec{IngressClassName: "a"},
+			}
+			ingress := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "grafana",
+					Namespace:   "monitoring",
+					Annotations: map[string]string{"kubernetes.io/ingress.class": "a"},
+				},
+				Spec: networkingv1.IngressSpec{
+					Rules: []networkingv1.IngressRule{{Host: "grafana.example.com"}},
+				},
+			}
+			reconciler := newFakeReconciler(dashboard, ingress)
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)}
+			configMap := &corev1.ConfigMap{}
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(dashboard), configMap)).To(Succeed())
+			Expect(configMap.Data["config.yml"]).To(ContainSubstring("grafana.example.com"))
+
+			ingress.Annotations["kubernetes.io/ingress.class"] = "b"
+			Expect(reconciler.Update(ctx, ingress)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(dashboard), configMap)).To(Succeed())
+			Expect(configMap.Data["config.yml"]).NotTo(ContainSubstring("grafana.example.com"))
+		})
+	})
+
+	Context("When the operator runs with an instance name", func() {
+		ctx := context.Background()
+
+		It("should only reconcile Dashboards of its instance and label what it creates", func() {
+			staging := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "staging",
+					Namespace: "default",
+					Labels:    map[string]string{homer.InstanceLabel: "staging"},
+				},
+			}
+			prod := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "default"},
+			}
+			reconciler := newFakeReconciler(staging, prod)
+			reconciler.InstanceName = "staging"
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(staging)})
+			Expect(err).NotTo(HaveOccurred())
+			configMap := &corev1.ConfigMap{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(staging), configMap)).To(Succeed())
+			Expect(configMap.Labels).To(HaveKeyWithValue(homer.InstanceLabel, "staging"))
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(prod)})
+			Expect(err).NotTo(HaveOccurred())
+			err = reconciler.Get(ctx, client.ObjectKeyFromObject(prod), &corev1.ConfigMap{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("When reconciling a Dashboard", func() {
+		ctx := context.Background()
+
+		It("should record Events for created resources and build failures", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+			}
+			reconciler := newFakeReconciler(dashboard)
+			events := reconciler.Recorder.(*record.FakeRecorder).Events
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)}
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(Receive(Equal("Normal Created Created Deployment dashboard")))
+
+			Expect(reconciler.Get(ctx, request.NamespacedName, dashboard)).To(Succeed())
+			dashboard.Spec.Secrets.ProxyHeaders = map[string]homerv1alpha1.SecretKeyRef{
+				"Authorization": {Name: "missing", Key: "token"},
+			}
+			Expect(reconciler.Update(ctx, dashboard)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).To(HaveOccurred())
+			Eventually(events).Should(Receive(HavePrefix("Warning ConfigBuildFailed")))
+		})
+
+		It("should report the outcome of a reconcile through status conditions", func() {
+			dashboard := &homerv1alpha1.Dashboard{ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"}}
+			reconciler := newFakeReconciler(dashboard)
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)}
+			condition := func(conditionType string) metav1.Condition {
+				Expect(reconciler.Get(ctx, request.NamespacedName, dashboard)).To(Succeed())
+				found := meta.FindStatusCondition(dashboard.Status.Conditions, conditionType)
+				Expect(found).NotTo(BeNil(), conditionType)
+				return *found
+			}
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(condition(homerv1alpha1.ConditionConfigRendered)).To(And(
+				HaveField("Status", metav1.ConditionTrue), HaveField("Reason", "Rendered")))
+			Expect(condition(homerv1alpha1.ConditionConfigValid)).To(And(
+				HaveField("Status", metav1.ConditionTrue), HaveField("Reason", "Valid")))
+			Expect(condition(homerv1alpha1.ConditionDeploymentAvailable)).To(And(
+				HaveField("Status", metav1.ConditionFalse), HaveField("Reason", "DeploymentUnavailable")))
+			Expect(condition(homerv1alpha1.ConditionReady)).To(And(
+				HaveField("Status", metav1.ConditionFalse), HaveField("Reason", "DeploymentUnavailable")))
+
+			deployment := &appsv1.Deployment{}
+			Expect(reconciler.Get(ctx, request.NamespacedName, deployment)).To(Succeed())
+			deployment.Status.Conditions = []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+			}
+			Expect(reconciler.Status().Update(ctx, deployment)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(condition(homerv1alpha1.ConditionDeploymentAvailable)).To(And(
+				HaveField("Status", metav1.ConditionTrue), HaveField("Reason", "DeploymentAvailable")))
+			Expect(condition(homerv1alpha1.ConditionReady)).To(And(
+				HaveField("Status", metav1.ConditionTrue), HaveField("Reason", "Ready")))
+
+			dashboard.Spec.HomerConfig.Colors = &homer.ColorConfig{Dark: &homer.ThemeColors{Text: "#zzz"}}
+			Expect(reconciler.Update(ctx, dashboard)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).To(HaveOccurred())
+			Expect(condition(homerv1alpha1.ConditionConfigRendered)).To(And(
+				HaveField("Status", metav1.ConditionFalse), HaveField("Reason", "ReconcileFailed"),
+				HaveField("Message", ContainSubstring("colors.dark.text"))))
+			Expect(condition(homerv1alpha1.ConditionReady)).To(And(
+				HaveField("Status", metav1.ConditionFalse), HaveField("Reason", "ReconcileFailed")))
+		})
+
+		It("should mark the config invalid when two sources define an item of the same name", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec: homerv1alpha1.DashboardSpec{HomerConfig: homer.HomerConfig{
+					Services: []homer.Service{{Name: "monitoring", Items: []homer.Item{{Name: "grafana"}}}},
+				}},
+			}
+			ingress := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "grafana", Namespace: "monitoring"},
+				Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: "grafana.example.com"}}},
+			}
+			reconciler := newFakeReconciler(dashboard, ingress)
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)}
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(reconciler.Get(ctx, request.NamespacedName, dashboard)).To(Succeed())
+			valid := meta.FindStatusCondition(dashboard.Status.Conditions, homerv1alpha1.ConditionConfigValid)
+			Expect(valid).NotTo(BeNil())
+			Expect(valid.Status).To(Equal(metav1.ConditionFalse))
+			Expect(valid.Reason).To(Equal("ValidationWarnings"))
+			Expect(valid.Message).To(ContainSubstring(
+				`item "grafana" is defined by both dashboard and ingress/monitoring/grafana`))
+		})
+
+		It("should leave the resources of a paused Dashboard alone", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec:       homerv1alpha1.DashboardSpec{Paused: true},
+			}
+			reconciler := newFakeReconciler(dashboard)
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)}
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = reconciler.Get(ctx, request.NamespacedName, &corev1.ConfigMap{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(reconciler.Get(ctx, request.NamespacedName, dashboard)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(dashboard.Status.Conditions, homerv1alpha1.ConditionPaused)).To(BeTrue())
+
+			dashboard.Spec.Paused = false
+			Expect(reconciler.Update(ctx, dashboard)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, request.NamespacedName, &corev1.ConfigMap{})).To(Succeed())
+			Expect(reconciler.Get(ctx, request.NamespacedName, dashboard)).To(Succeed())
+			Expect(meta.FindStatusCondition(dashboard.Status.Conditions, homerv1alpha1.ConditionPaused)).To(BeNil())
+		})
+
+		It("should apply the pod template to the Homer Deployment", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec: homerv1alpha1.DashboardSpec{
+					PodTemplate: &homerv1alpha1.PodTemplate{
+						NodeSelector:      map[string]string{"kubernetes.io/arch": "arm64"},
+						Tolerations:       []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+						PriorityClassName: "low",
+					},
+				},
+			}
+			reconciler := newFakeReconciler(dashboard)
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)})
+			Expect(err).NotTo(HaveOccurred())
+
+			deployment := &appsv1.Deployment{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(dashboard), deployment)).To(Succeed())
+			pod := deployment.Spec.Template.Spec
+			Expect(pod.NodeSelector).To(HaveKeyWithValue("kubernetes.io/arch", "arm64"))
+			Expect(pod.Tolerations).To(HaveLen(1))
+			Expect(pod.PriorityClassName).To(Equal("low"))
+			Expect(pod.SecurityContext).To(BeNil())
+		})
+
+		It("should apply the service settings to the Homer Service", func() {
+			policy := corev1.IPFamilyPolicyPreferDualStack
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec: homerv1alpha1.DashboardSpec{
+					Service: &homerv1alpha1.ServiceConfig{
+						Type:           corev1.ServiceTypeLoadBalancer,
+						IPFamilyPolicy: &policy,
+						IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+					},
+				},
+			}
+			reconciler := newFakeReconciler(dashboard)
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)})
+			Expect(err).NotTo(HaveOccurred())
+
+			service := &corev1.Service{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(dashboard), service)).To(Succeed())
+			Expect(service.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+			Expect(*service.Spec.IPFamilyPolicy).To(Equal(policy))
+			Expect(service.Spec.IPFamilies).To(Equal([]corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}))
+		})
+
+		It("should add user metadata without overriding the operator's labels", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec: homerv1alpha1.DashboardSpec{
+					Service: &homerv1alpha1.ServiceConfig{
+						Annotations: map[string]string{"external-dns.alpha.kubernetes.io/hostname": "home.example.com"},
+					},
+					Deployment: &homerv1alpha1.DeploymentConfig{
+						Annotations: map[string]string{"team": "platform"},
+						Labels:      map[string]string{"app": "homer", "managed-by": "someone-else"},
+					},
+				},
+			}
+			reconciler := newFakeReconciler(dashboard)
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)})
+			Expect(err).NotTo(HaveOccurred())
+
+			service := &corev1.Service{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(dashboard), service)).To(Succeed())
+			Expect(service.Annotations).To(HaveKeyWithValue("external-dns.alpha.kubernetes.io/hostname", "home.example.com"))
+			deployment := &appsv1.Deployment{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(dashboard), deployment)).To(Succeed())
+			Expect(deployment.Annotations).To(HaveKeyWithValue("team", "platform"))
+			Expect(deployment.Labels).To(HaveKeyWithValue("app", "homer"))
+			Expect(deployment.Labels).To(HaveKeyWithValue("managed-by", "homer-operator"))
+		})
+
+		It("should mount the extra volumes into the Homer container", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec: homerv1alpha1.DashboardSpec{
+					ExtraVolumes: []corev1.Volume{{
+						Name: "icons",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "homer-icons"},
+						},
+					}},
+					ExtraVolumeMounts: []corev1.VolumeMount{{Name: "icons", MountPath: "/www/assets/icons"}},
+				},
+			}
+			reconciler := newFakeReconciler(dashboard)
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)})
+			Expect(err).NotTo(HaveOccurred())
+
+			deployment := &appsv1.Deployment{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(dashboard), deployment)).To(Succeed())
+			pod := deployment.Spec.Template.Spec
+			Expect(pod.Volumes).To(HaveLen(2))
+			Expect(pod.Volumes[1].PersistentVolumeClaim.ClaimName).To(Equal("homer-icons"))
+			Expect(pod.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "icons", MountPath: "/www/assets/icons"}))
+		})
+
+		It("should serve Homer under spec.baseURL", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec:       homerv1alpha1.DashboardSpec{BaseURL: "/homer/"},
+			}
+			reconciler := newFakeReconciler(dashboard)
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)})
+			Expect(err).NotTo(HaveOccurred())
+
+			deployment := &appsv1.Deployment{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(dashboard), deployment)).To(Succeed())
+			Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(ConsistOf(corev1.EnvVar{Name: "SUBFOLDER", Value: "/homer"}))
+		})
+
+		It("should mount the config under spec.homerAssetsPath and probe that Homer serves it", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec: homerv1alpha1.DashboardSpec{
+					HomerAssetsPath:    "/config/",
+					VerifyConfigServed: true,
+					BaseURL:            "/homer",
+					ConfigKey:          "dashboard.yml",
+					Assets:             &homerv1alpha1.AssetsConfig{Files: map[string][]byte{"logo.png": {1}}},
+				},
+			}
+			reconciler := newFakeReconciler(dashboard)
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)})
+			Expect(err).NotTo(HaveOccurred())
+
+			deployment := &appsv1.Deployment{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(dashboard), deployment)).To(Succeed())
+			container := deployment.Spec.Template.Spec.Containers[0]
+			Expect(container.VolumeMounts).To(ConsistOf(
+				corev1.VolumeMount{Name: "config-volume", MountPath: "/config"},
+				corev1.VolumeMount{Name: "assets-volume", MountPath: "/config/custom"},
+			))
+			Expect(container.StartupProbe).NotTo(BeNil())
+			Expect(container.StartupProbe.HTTPGet.Path).To(Equal("/homer/assets/dashboard.yml"))
+		})
+
+		It("should render the title templates with the cluster name and item counts", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec: homerv1alpha1.DashboardSpec{
+					HomerConfig: homer.HomerConfig{
+						Title:    "Apps on {{ .ClusterName }}",
+						Subtitle: "{{ .Items }} apps",
+					},
+				},
+			}
+			ingress := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "grafana", Namespace: "monitoring"},
+				Spec: networkingv1.IngressSpec{
+					Rules: []networkingv1.IngressRule{{Host: "grafana.example.com"}},
+				},
+			}
+			reconciler := newFakeReconciler(dashboard, ingress)
+			reconciler.ClusterName = "prod-eu"
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)})
+			Expect(err).NotTo(HaveOccurred())
+
+			configMap := &corev1.ConfigMap{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(dashboard), configMap)).To(Succeed())
+			Expect(configMap.Data["config.yml"]).To(ContainSubstring("title: Apps on prod-eu"))
+			Expect(configMap.Data["config.yml"]).To(ContainSubstring("subtitle: 1 apps"))
+		})
+
+		It("should serve the assets declared in the Dashboard", func() {
+			logo := []byte{0x89, 'P', 'N', 'G'}
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec: homerv1alpha1.DashboardSpec{
+					Assets: &homerv1alpha1.AssetsConfig{
+						Files:       map[string][]byte{"logo.png": logo},
+						Stylesheets: map[string]string{"custom.css": "body { color: teal; }"},
+					},
+				},
+			}
+			reconciler := newFakeReconciler(dashboard)
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)}
+			assetsKey := client.ObjectKey{Namespace: "default", Name: "dashboard-homer-assets"}
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			assets := &corev1.ConfigMap{}
+			Expect(reconciler.Get(ctx, assetsKey, assets)).To(Succeed())
+			Expect(assets.BinaryData).To(HaveKeyWithValue("logo.png", logo))
+			Expect(assets.Data).To(HaveKeyWithValue("custom.css", "body { color: teal; }"))
+			configMap := &corev1.ConfigMap{}
+			Expect(reconciler.Get(ctx, request.NamespacedName, configMap)).To(Succeed())
+			Expect(configMap.Data["config.yml"]).To(ContainSubstring("- assets/custom/custom.css"))
+			deployment := &appsv1.Deployment{}
+			Expect(reconciler.Get(ctx, request.NamespacedName, deployment)).To(Succeed())
+			Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(
+				corev1.VolumeMount{Name: "assets-volume", MountPath: "/www/assets/custom"}))
+
+			Expect(reconciler.Get(ctx, request.NamespacedName, dashboard)).To(Succeed())
+			dashboard.Spec.Assets = nil
+			Expect(reconciler.Update(ctx, dashboard)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			err = reconciler.Get(ctx, assetsKey, &corev1.ConfigMap{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should roll the Homer pods on config changes when asked to", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec: homerv1alpha1.DashboardSpec{
+					HomerConfig:           homer.HomerConfig{Title: "Dashboard"},
+					RestartOnConfigChange: true,
+				},
+			}
+			reconciler := newFakeReconciler(dashboard)
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)}
+			checksum := func() string {
+				deployment := &appsv1.Deployment{}
+				Expect(reconciler.Get(ctx, request.NamespacedName, deployment)).To(Succeed())
+				return deployment.Spec.Template.Annotations[homer.ConfigChecksumAnnotation]
+			}
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			configMap := &corev1.ConfigMap{}
+			Expect(reconciler.Get(ctx, request.NamespacedName, configMap)).To(Succeed())
+			first := checksum()
+			Expect(first).To(Equal(configMap.Annotations[homer.ConfigChecksumAnnotation]))
+
+			Expect(reconciler.Get(ctx, request.NamespacedName, dashboard)).To(Succeed())
+			dashboard.Spec.HomerConfig.Title = "Apps"
+			Expect(reconciler.Update(ctx, dashboard)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checksum()).NotTo(Or(BeEmpty(), Equal(first)))
+		})
+
+		It("should add Traefik IngressRoutes when Traefik discovery is enabled", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+			}
+			route := newIngressRoute()
+			route.SetName("whoami")
+			route.SetNamespace("apps")
+			Expect(unstructured.SetNestedSlice(route.Object, []interface{}{
+				map[string]interface{}{"match": "Host(`whoami.example.com`) && PathPrefix(`/`)"},
+			}, "spec", "routes")).To(Succeed())
+			Expect(unstructured.SetNestedMap(route.Object, map[string]interface{}{}, "spec", "tls")).To(Succeed())
+			reconciler := newFakeReconciler(dashboard, route)
+			reconciler.EnableTraefik = true
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)})
+			Expect(err).NotTo(HaveOccurred())
+
+			configMap := &corev1.ConfigMap{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(dashboard), configMap)).To(Succeed())
+			Expect(configMap.Data["config.yml"]).To(ContainSubstring("url: https://whoami.example.com"))
+		})
+
+		It("should only discover EndpointSlices when EndpointSlice discovery is enabled", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec:       homerv1alpha1.DashboardSpec{DiscoverEndpointSlices: true},
+			}
+			other := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default",
+					Labels: map[string]string{homer.InstanceLabel: "other"}},
+				Spec: homerv1alpha1.DashboardSpec{DiscoverEndpointSlices: true},
+			}
+			plain := &homerv1alpha1.Dashboard{ObjectMeta: metav1.ObjectMeta{Name: "plain", Namespace: "default"}}
+			ready, port := true, int32(8080)
+			slice := &discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{Name: "tool-abcde", Namespace: "internal", Labels: map[string]string{
+					discoveryv1.LabelServiceName: "tool", headlessServiceLabel: "",
+				}},
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints: []discoveryv1.Endpoint{
+					{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: &ready}},
+				},
+				Ports: []discoveryv1.EndpointPort{{Port: &port}},
+			}
+			reconciler := newFakeReconciler(dashboard, other, plain, slice)
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)}
+			Expect(reconciler.findDashboardsForEndpointSlice(ctx, slice)).To(ConsistOf(request))
+
+			configMap := &corev1.ConfigMap{}
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(dashboard), configMap)).To(Succeed())
+			Expect(configMap.Data["config.yml"]).NotTo(ContainSubstring("http://10.0.0.1:8080"))
+
+			reconciler.EnableEndpointSlices = true
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(dashboard), configMap)).To(Succeed())
+			Expect(configMap.Data["config.yml"]).To(ContainSubstring("http://10.0.0.1:8080"))
+		})
+
+		It("should drop a top navigation link once its ingress stops providing it", func() {
+			dashboard := &homerv1alpha1.Dashboard{ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"}}
+			linked := func(name string) *networkingv1.Ingress {
+				return &networkingv1.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: name, Namespace: "docs",
+						Annotations: map[string]string{"link.homer.rajsingh.info/name": name},
+					},
+					Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: name + ".example.com"}}},
+				}
+			}
+			wiki, handbook := linked("wiki"), linked("handbook")
+			reconciler := newFakeReconciler(dashboard, wiki, handbook)
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)}
+			links := func() []homer.Link {
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				configMap := &corev1.ConfigMap{}
+				Expect(reconciler.Get(ctx, request.NamespacedName, configMap)).To(Succeed())
+				config, err := homer.LoadHomerConfig(configMap.Data["config.yml"])
+				Expect(err).NotTo(HaveOccurred())
+				return config.Links
+			}
+			Expect(links()).To(ConsistOf(
+				HaveField("Name", "wiki"), HaveField("Name", "handbook")))
+
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(wiki), wiki)).To(Succeed())
+			delete(wiki.Annotations, "link.homer.rajsingh.info/name")
+			Expect(reconciler.Update(ctx, wiki)).To(Succeed())
+			Expect(reconciler.Delete(ctx, handbook)).To(Succeed())
+			Expect(links()).To(BeEmpty())
+		})
+
+		It("should write the inventory of discovered items when asked to", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec:       homerv1alpha1.DashboardSpec{EmitInventory: true},
+			}
+			ingress := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "grafana", Namespace: "monitoring"},
+				Spec: networkingv1.IngressSpec{
+					Rules: []networkingv1.IngressRule{{Host: "grafana.example.com"}},
+				},
+			}
+			reconciler := newFakeReconciler(dashboard, ingress)
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)}
+			inventoryKey := client.ObjectKey{Namespace: "default", Name: "dashboard-homer-inventory"}
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			inventory := &corev1.ConfigMap{}
+			Expect(reconciler.Get(ctx, inventoryKey, inventory)).To(Succeed())
+			var entries []homer.InventoryEntry
+			Expect(json.Unmarshal([]byte(inventory.Data["inventory.json"]), &entries)).To(Succeed())
+			Expect(entries).To(ConsistOf(homer.InventoryEntry{
+				Service: "monitoring", Item: "grafana", URL: "http://grafana.example.com",
+				Host: "grafana.example.com", Kind: "ingress", Namespace: "monitoring", Name: "grafana",
+			}))
+
+			Expect(reconciler.Get(ctx, request.NamespacedName, dashboard)).To(Succeed())
+			dashboard.Spec.EmitInventory = false
+			Expect(reconciler.Update(ctx, dashboard)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			err = reconciler.Get(ctx, inventoryKey, &corev1.ConfigMap{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should only be ready once the caches synced and a Dashboard was reconciled", func() {
+			dashboard := &homerv1alpha1.Dashboard{ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"}}
+			probe := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			synced := false
+			waitForCacheSync := func(context.Context) bool { return synced }
+			elected := make(chan struct{})
+			close(elected)
+
+			check := newFakeReconciler().ReadyzCheck(waitForCacheSync, elected)
+			Expect(check(probe)).To(MatchError(ContainSubstring("synced")))
+			synced = true
+			Expect(check(probe)).To(Succeed())
+
+			reconciler := newFakeReconciler(dashboard)
+			check = reconciler.ReadyzCheck(waitForCacheSync, elected)
+			Expect(check(probe)).To(MatchError(ContainSubstring("reconcile")))
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(check(probe)).To(Succeed())
+		})
+
+		It("should be ready as a standby replica once the caches synced", func() {
+			dashboard := &homerv1alpha1.Dashboard{ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"}}
+			probe := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			check := newFakeReconciler(dashboard).ReadyzCheck(func(context.Context) bool { return true },
+				make(chan struct{}))
+			Expect(check(probe)).To(Succeed())
+		})
+
+		It("should be ready once a reconcile completed even if it failed", func() {
+			failing := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec: homerv1alpha1.DashboardSpec{
+					HomerConfig: homer.HomerConfig{
+						Colors: &homer.ColorConfig{Dark: &homer.ThemeColors{Text: "#zzz"}},
+					},
+				},
+			}
+			probe := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			elected := make(chan struct{})
+			close(elected)
+			reconciler := newFakeReconciler(failing)
+			check := reconciler.ReadyzCheck(func(context.Context) bool { return true }, elected)
+			Expect(check(probe)).To(MatchError(ContainSubstring("reconcile")))
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(failing)})
+			Expect(err).To(HaveOccurred())
+			Expect(check(probe)).To(Succeed())
+		})
+
+		It("should pass the concurrency limit to the controller", func() {
+			reconciler := newFakeReconciler()
+			Expect(reconciler.controllerOptions().MaxConcurrentReconciles).To(Equal(1))
+			reconciler.MaxConcurrentReconciles = 4
+			Expect(reconciler.controllerOptions().MaxConcurrentReconciles).To(Equal(4))
+		})
+
+		It("should write why each ingress was excluded when debugging", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec: homerv1alpha1.DashboardSpec{
+					Debug: true, IngressClassName: "nginx", DomainFilters: []string{"example.com"},
+					ExcludeNamePatterns: []string{"*-tmp"}, ExcludeHostPatterns: []string{"*-canary.example.com"},
+				},
+			}
+			nginx, traefik := "nginx", "traefik"
+			withHost := func(name, host string, annotations map[string]string) *networkingv1.Ingress {
+				return &networkingv1.Ingress{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "monitoring", Annotations: annotations},
+					Spec: networkingv1.IngressSpec{IngressClassName: &nginx,
+						Rules: []networkingv1.IngressRule{{Host: host}}},
+				}
+			}
+			ingresses := []*networkingv1.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "grafana", Namespace: "monitoring"},
+					Spec: networkingv1.IngressSpec{IngressClassName: &nginx,
+						Rules: []networkingv1.IngressRule{{Host: "grafana.example.com"}}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: "monitoring"},
+					Spec: networkingv1.IngressSpec{IngressClassName: &nginx,
+						Rules: []networkingv1.IngressRule{{Host: "internal.corp"}}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "monitoring"},
+					Spec: networkingv1.IngressSpec{IngressClassName: &traefik,
+						Rules: []networkingv1.IngressRule{{Host: "other.example.com"}}},
+				},
+				withHost("grafana-tmp", "grafana-tmp.example.com", nil),
+				withHost("canary", "grafana-canary.example.com", nil),
+				withHost("seasonal", "seasonal.example.com", map[string]string{
+					"item.homer.rajsingh.info/visible-from": time.Now().Add(time.Hour).Format(time.RFC3339),
+				}),
+			}
+			objects := []client.Object{dashboard}
+			for _, ingress := range ingresses {
+				objects = append(objects, ingress)
+			}
+			reconciler := newFakeReconciler(objects...)
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)}
+			debugKey := client.ObjectKey{Namespace: "default", Name: "dashboard-homer-debug"}
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			debug := &corev1.ConfigMap{}
+			Expect(reconciler.Get(ctx, debugKey, debug)).To(Succeed())
+			var entries []homer.DebugEntry
+			Expect(json.Unmarshal([]byte(debug.Data["debug.json"]), &entries)).To(Succeed())
+			Expect(entries).To(ConsistOf(
+				homer.DebugEntry{Kind: "ingress", Namespace: "monitoring", Name: "grafana", Reason: "included"},
+				homer.DebugEntry{Kind: "ingress", Namespace: "monitoring", Name: "internal",
+					Reason: "no host matches the domain filters"},
+				homer.DebugEntry{Kind: "ingress", Namespace: "monitoring", Name: "other",
+					Reason: "ingress class does not match"},
+				homer.DebugEntry{Kind: "ingress", Namespace: "monitoring", Name: "grafana-tmp",
+					Reason: "name matches the exclude name patterns"},
+				homer.DebugEntry{Kind: "ingress", Namespace: "monitoring", Name: "canary",
+					Reason: "every host matches the exclude host patterns"},
+				homer.DebugEntry{Kind: "ingress", Namespace: "monitoring", Name: "seasonal",
+					Reason: "hidden outside its visibility window"},
+			))
+
+			Expect(reconciler.Get(ctx, request.NamespacedName, dashboard)).To(Succeed())
+			dashboard.Spec.Debug = false
+			Expect(reconciler.Update(ctx, dashboard)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			err = reconciler.Get(ctx, debugKey, &corev1.ConfigMap{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should serve a Dashboard with a deploymentRef as a page of the referenced Deployment", func() {
+			main := &homerv1alpha1.Dashboard{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"}}
+			team := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "default"},
+				Spec:       homerv1alpha1.DashboardSpec{DeploymentRef: "main"},
+			}
+			reconciler := newFakeReconciler(main, team)
+			for _, dashboard := range []*homerv1alpha1.Dashboard{main, team} {
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			teamKey := client.ObjectKey{Namespace: "default", Name: "team"}
+			Expect(reconciler.Get(ctx, teamKey, &corev1.ConfigMap{})).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, teamKey, &appsv1.Deployment{}))).To(BeTrue())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, teamKey, &corev1.Service{}))).To(BeTrue())
+
+			deployment := &appsv1.Deployment{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: "default", Name: "main"}, deployment)).To(Succeed())
+			volume := deployment.Spec.Template.Spec.Volumes[0]
+			Expect(volume.Projected).NotTo(BeNil())
+			Expect(volume.Projected.Sources).To(HaveLen(2))
+			Expect(volume.Projected.Sources[0].ConfigMap.Name).To(Equal("main"))
+			Expect(volume.Projected.Sources[1].ConfigMap.Name).To(Equal("team"))
+			Expect(volume.Projected.Sources[1].ConfigMap.Items).To(ConsistOf(
+				corev1.KeyToPath{Key: "config.yml", Path: "team.yml"}))
+		})
+
+		It("should reject a deploymentRef naming the Dashboard itself without deleting its Deployment", func() {
+			dashboard := &homerv1alpha1.Dashboard{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"}}
+			reconciler := newFakeReconciler(dashboard)
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)}
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(reconciler.Get(ctx, request.NamespacedName, dashboard)).To(Succeed())
+			dashboard.Spec.DeploymentRef = "main"
+			Expect(reconciler.Update(ctx, dashboard)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).To(MatchError(ContainSubstring("names the Dashboard itself")))
+			Expect(reconciler.Get(ctx, request.NamespacedName, &appsv1.Deployment{})).To(Succeed())
+			Expect(reconciler.Get(ctx, request.NamespacedName, &corev1.Service{})).To(Succeed())
+			Expect(reconciler.Get(ctx, request.NamespacedName, dashboard)).To(Succeed())
+			Expect(meta.IsStatusConditionFalse(dashboard.Status.Conditions, homerv1alpha1.ConditionReady)).To(BeTrue())
+		})
+
+		It("should reject a deploymentRef naming a Dashboard with a deploymentRef", func() {
+			main := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+				Spec:       homerv1alpha1.DashboardSpec{DeploymentRef: "root"},
+			}
+			team := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "default"},
+				Spec:       homerv1alpha1.DashboardSpec{DeploymentRef: "main"},
+			}
+			reconciler := newFakeReconciler(main, team)
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(team)}
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).To(MatchError(ContainSubstring("deploymentRef of its own")))
+			Expect(errors.IsNotFound(reconciler.Get(ctx, request.NamespacedName, &corev1.ConfigMap{}))).To(BeTrue())
+			Expect(reconciler.Get(ctx, request.NamespacedName, team)).To(Succeed())
+			ready := meta.FindStatusCondition(team.Status.Conditions, homerv1alpha1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Message).To(ContainSubstring("deploymentRef of its own"))
+		})
+
+		It("should leave a manually overridden ConfigMap alone until the annotation is removed", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec:       homerv1alpha1.DashboardSpec{HomerConfig: homer.HomerConfig{Title: "Generated"}},
+			}
+			reconciler := newFakeReconciler(dashboard)
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)}
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			configMap := &corev1.ConfigMap{}
+			Expect(reconciler.Get(ctx, request.NamespacedName, configMap)).To(Succeed())
+			configMap.Annotations[homer.ManualOverrideAnnotation] = "true"
+			configMap.Data["config.yml"] = "title: Hand-edited\n"
+			Expect(reconciler.Update(ctx, configMap)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(reconciler.Get(ctx, request.NamespacedName, configMap)).To(Succeed())
+			Expect(configMap.Data["config.yml"]).To(Equal("title: Hand-edited\n"))
+			Expect(reconciler.Get(ctx, request.NamespacedName, dashboard)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(dashboard.Status.Conditions, homerv1alpha1.ConditionManualOverride)).To(BeTrue())
+
+			delete(configMap.Annotations, homer.ManualOverrideAnnotation)
+			Expect(reconciler.Update(ctx, configMap)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(reconciler.Get(ctx, request.NamespacedName, configMap)).To(Succeed())
+			Expect(configMap.Data["config.yml"]).To(ContainSubstring("title: Generated"))
+			Expect(reconciler.Get(ctx, request.NamespacedName, dashboard)).To(Succeed())
+			Expect(meta.FindStatusCondition(dashboard.Status.Conditions, homerv1alpha1.ConditionManualOverride)).To(BeNil())
+		})
+
+		It("should fail a strict Dashboard whose config does not match the Homer schema", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+				Spec: homerv1alpha1.DashboardSpec{
+					ValidationLevel:  homerv1alpha1.ValidationLevelStrict,
+					YAMLKeyOverrides: map[string]string{"title": "titel"},
+				},
+			}
+			reconciler := newFakeReconciler(dashboard)
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)}
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).To(MatchError(ContainSubstring("config.titel")))
+
+			Expect(reconciler.Get(ctx, request.NamespacedName, dashboard)).To(Succeed())
+			Expect(meta.IsStatusConditionFalse(dashboard.Status.Conditions, homerv1alpha1.ConditionConfigRendered)).To(BeTrue())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, request.NamespacedName, &corev1.ConfigMap{}))).To(BeTrue())
+
+			dashboard.Spec.YAMLKeyOverrides = map[string]string{"apikey": "apiKey"}
+			Expect(reconciler.Update(ctx, dashboard)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should hide an item before its visibility window and requeue when it opens", func() {
+			dashboard := &homerv1alpha1.Dashboard{ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"}}
+			ingress := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "advent",
+					Namespace: "default",
+					Annotations: map[string]string{
+						"item.homer.rajsingh.info/visible-from": time.Now().Add(time.Hour).Format(time.RFC3339),
+					},
+				},
+				Spec: networkingv1.IngressSpec{
+					Rules: []networkingv1.IngressRule{{Host: "advent.example.com"}},
+				},
+			}
+			reconciler := newFakeReconciler(dashboard, ingress)
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dashboard)}
+			result, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+
+			configMap := &corev1.ConfigMap{}
+			Expect(reconciler.Get(ctx, request.NamespacedName, configMap)).To(Succeed())
+			Expect(configMap.Data["config.yml"]).NotTo(ContainSubstring("advent"))
+		})
+	})
 })
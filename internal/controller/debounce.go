@@ -0,0 +1,100 @@
+/*
+Copyright 2024 RajSingh.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// debounceMaxWindows caps how many windows a request is held back while events keep
+// arriving, so a resource that changes all the time does not starve its Dashboards.
+const debounceMaxWindows = 10
+
+// debouncedEnqueue returns a handler enqueueing the requests of fn once no event mapped to
+// them arrived for window, so a burst of events, such as a rollout touching many ingresses,
+// results in one reconcile per Dashboard after the burst. A request is enqueued at the
+// latest debounceMaxWindows windows after its first event. A window of zero enqueues
+// right away.
+func debouncedEnqueue(window time.Duration, fn handler.MapFunc) handler.EventHandler {
+	if window <= 0 {
+		return handler.EnqueueRequestsFromMapFunc(fn)
+	}
+	d := &debouncer{window: window, pending: map[reconcile.Request]*pendingRequest{}}
+	enqueue := func(ctx context.Context, obj client.Object, q workqueue.RateLimitingInterface) {
+		for _, request := range fn(ctx, obj) {
+			d.schedule(request, q)
+		}
+	}
+	return handler.Funcs{
+		CreateFunc: func(ctx context.Context, e event.CreateEvent, q workqueue.RateLimitingInterface) {
+			enqueue(ctx, e.Object, q)
+		},
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			enqueue(ctx, e.ObjectOld, q)
+			enqueue(ctx, e.ObjectNew, q)
+		},
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+			enqueue(ctx, e.Object, q)
+		},
+		GenericFunc: func(ctx context.Context, e event.GenericEvent, q workqueue.RateLimitingInterface) {
+			enqueue(ctx, e.Object, q)
+		},
+	}
+}
+
+// debouncer holds back requests until their events stop for window.
+type debouncer struct {
+	window  time.Duration
+	mu      sync.Mutex
+	pending map[reconcile.Request]*pendingRequest
+}
+
+// pendingRequest is a request waiting for its timer.
+type pendingRequest struct {
+	timer    *time.Timer
+	deadline time.Time
+}
+
+// schedule enqueues request after window, pushing back a pending enqueue of the same
+// request unless that would pass its deadline.
+func (d *debouncer) schedule(request reconcile.Request, q workqueue.RateLimitingInterface) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := time.Now()
+	if pending, ok := d.pending[request]; ok {
+		if delay := min(d.window, pending.deadline.Sub(now)); delay > 0 && pending.timer.Stop() {
+			pending.timer.Reset(delay)
+		}
+		return
+	}
+	pending := &pendingRequest{deadline: now.Add(debounceMaxWindows * d.window)}
+	pending.timer = time.AfterFunc(d.window, func() {
+		d.mu.Lock()
+		delete(d.pending, request)
+		d.mu.Unlock()
+		q.Add(request)
+	})
+	d.pending[request] = pending
+}
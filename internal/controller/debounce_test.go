@@ -0,0 +1,88 @@
+/*
+Copyright 2024 RajSingh.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Debounced enqueueing", func() {
+	It("should coalesce a burst of events into one request per Dashboard", func() {
+		ctx := context.Background()
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "dashboard"}}
+		toDashboard := func(context.Context, client.Object) []reconcile.Request {
+			return []reconcile.Request{request}
+		}
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+
+		eventHandler := debouncedEnqueue(50*time.Millisecond, toDashboard)
+		for i := 0; i < 10; i++ {
+			eventHandler.Create(ctx, event.CreateEvent{Object: &networkingv1.Ingress{}}, queue)
+		}
+		Expect(queue.Len()).To(Equal(0))
+		Eventually(queue.Len).Should(Equal(1))
+		Consistently(queue.Len, 100*time.Millisecond).Should(Equal(1))
+	})
+
+	It("should hold a request back while events keep arriving", func() {
+		ctx := context.Background()
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "dashboard"}}
+		toDashboard := func(context.Context, client.Object) []reconcile.Request {
+			return []reconcile.Request{request}
+		}
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+
+		eventHandler := debouncedEnqueue(100*time.Millisecond, toDashboard)
+		for i := 0; i < 15; i++ {
+			eventHandler.Create(ctx, event.CreateEvent{Object: &networkingv1.Ingress{}}, queue)
+			time.Sleep(20 * time.Millisecond)
+		}
+		Expect(queue.Len()).To(Equal(0))
+		Eventually(queue.Len).Should(Equal(1))
+	})
+
+	It("should enqueue a request held back for too long even if events keep arriving", func() {
+		ctx := context.Background()
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "dashboard"}}
+		toDashboard := func(context.Context, client.Object) []reconcile.Request {
+			return []reconcile.Request{request}
+		}
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+
+		window := 20 * time.Millisecond
+		eventHandler := debouncedEnqueue(window, toDashboard)
+		deadline := time.Now().Add(3 * debounceMaxWindows * window)
+		for time.Now().Before(deadline) && queue.Len() == 0 {
+			eventHandler.Create(ctx, event.CreateEvent{Object: &networkingv1.Ingress{}}, queue)
+			time.Sleep(window / 4)
+		}
+		Expect(queue.Len()).To(Equal(1))
+	})
+})
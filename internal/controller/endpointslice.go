@@ -0,0 +1,87 @@
+/*
+Copyright 2024 RajSingh.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	homerv1alpha1 "github.com/rajsinghtech/homer-operator.git/api/v1alpha1"
+	homer "github.com/rajsinghtech/homer-operator.git/pkg/homer"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// headlessServiceLabel is set by the EndpointSlice controller on the slices of headless Services.
+const headlessServiceLabel = "service.kubernetes.io/headless"
+
+// addEndpointSlices adds the endpoints of the EndpointSlices selected by the Dashboard to config
+// when the operator runs with EndpointSlice discovery enabled.
+func (r *DashboardReconciler) addEndpointSlices(ctx context.Context, dashboard *homerv1alpha1.Dashboard, config *homer.HomerConfig) error {
+	if !r.EnableEndpointSlices || !dashboard.Spec.DiscoverEndpointSlices {
+		return nil
+	}
+	selector, err := endpointSliceSelector(dashboard)
+	if err != nil {
+		return err
+	}
+	sliceList := &discoveryv1.EndpointSliceList{}
+	if err := r.List(ctx, sliceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("unable to list EndpointSlices: %w", err)
+	}
+	for _, slice := range sliceList.Items {
+		homer.UpdateHomerConfigEndpointSlice(config, slice)
+	}
+	return nil
+}
+
+// endpointSliceSelector returns the selector for the Dashboard's EndpointSlices,
+// which defaults to the slices of headless Services.
+func endpointSliceSelector(dashboard *homerv1alpha1.Dashboard) (labels.Selector, error) {
+	if dashboard.Spec.EndpointSliceSelector == nil {
+		return labels.SelectorFromSet(labels.Set{headlessServiceLabel: ""}), nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(dashboard.Spec.EndpointSliceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpointSliceSelector: %w", err)
+	}
+	return selector, nil
+}
+
+// findDashboardsForEndpointSlice maps an EndpointSlice event to the Dashboards of this instance
+// discovering EndpointSlices, so endpoints that are no longer selected or ready drop out on rebuild.
+func (r *DashboardReconciler) findDashboardsForEndpointSlice(ctx context.Context, obj client.Object) []reconcile.Request {
+	var dashboardList homerv1alpha1.DashboardList
+	if err := r.List(ctx, &dashboardList); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list Dashboards", "endpointslice", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, dashboard := range dashboardList.Items {
+		if dashboard.Spec.DiscoverEndpointSlices && !dashboard.Spec.Paused && r.ownsInstance(&dashboard) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: dashboard.Namespace, Name: dashboard.Name},
+			})
+		}
+	}
+	return requests
+}
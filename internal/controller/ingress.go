@@ -0,0 +1,152 @@
+/*
+Copyright 2024 RajSingh.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	homerv1alpha1 "github.com/rajsinghtech/homer-operator.git/api/v1alpha1"
+	homer "github.com/rajsinghtech/homer-operator.git/pkg/homer"
+	"github.com/rajsinghtech/homer-operator.git/pkg/utils"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// findDashboardsForIngress maps an Ingress or IngressRoute event to the Dashboards of
+// this instance that are not paused and may discover an item from it. Updates map both
+// the old and the new object, so a Dashboard the resource stopped matching is enqueued
+// too and drops its item when its config is rebuilt.
+func (r *DashboardReconciler) findDashboardsForIngress(ctx context.Context, obj client.Object) []reconcile.Request {
+	var dashboardList homerv1alpha1.DashboardList
+	if err := r.List(ctx, &dashboardList); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list Dashboards", "ingress", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, dashboard := range dashboardList.Items {
+		if dashboard.Spec.Paused || !r.ownsInstance(&dashboard) || !mayDiscover(&dashboard, obj) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: dashboard.Namespace, Name: dashboard.Name},
+		})
+	}
+	return requests
+}
+
+// mayDiscover reports whether the Dashboard's filters let it discover an item from obj.
+// Ingresses go through shouldIncludeIngress; IngressRoutes only need to pass the name patterns.
+func mayDiscover(dashboard *homerv1alpha1.Dashboard, obj client.Object) bool {
+	if ingress, ok := obj.(*networkingv1.Ingress); ok {
+		return shouldIncludeIngress(dashboard, ingress)
+	}
+	return !utils.MatchesNamePatterns(obj.GetName(), dashboard.Spec.ExcludeNamePatterns)
+}
+
+// ingressClassIndex indexes ingresses by their ingress class, so a Dashboard with an
+// IngressClassName only lists the ingresses of that class.
+const ingressClassIndex = "spec.ingressClassName"
+
+// indexIngressClass is the indexer of ingressClassIndex. It falls back to the legacy
+// kubernetes.io/ingress.class annotation, like matchesIngressClass.
+func indexIngressClass(obj client.Object) []string {
+	ingress, ok := obj.(*networkingv1.Ingress)
+	if !ok {
+		return nil
+	}
+	if ingress.Spec.IngressClassName != nil {
+		return []string{*ingress.Spec.IngressClassName}
+	}
+	if className := ingress.Annotations["kubernetes.io/ingress.class"]; className != "" {
+		return []string{className}
+	}
+	return nil
+}
+
+// listCandidateIngresses lists the ingresses that may match the Dashboard. When the
+// Dashboard has an IngressClassName, only ingresses of that class are listed from
+// ingressClassIndex instead of every ingress in the cluster; shouldIncludeIngress
+// still has to be checked for each. Without one every ingress is a candidate, since
+// ingresses no longer need to carry the Dashboard's annotations, so that path lists
+// them all on purpose. With debug set every ingress is listed, so the ones of other
+// classes are reported too.
+func (r *DashboardReconciler) listCandidateIngresses(ctx context.Context, dashboard *homerv1alpha1.Dashboard) (
+	*networkingv1.IngressList, error) {
+	ingressList := &networkingv1.IngressList{}
+	if dashboard.Spec.IngressClassName == "" || dashboard.Spec.Debug {
+		return ingressList, r.List(ctx, ingressList)
+	}
+	return ingressList, r.List(ctx, ingressList,
+		client.MatchingFields{ingressClassIndex: dashboard.Spec.IngressClassName})
+}
+
+// EvaluateIngress runs the Dashboard's discovery filters on the ingress at the current
+// time without touching the cluster, so the same decisions can be reported outside the
+// reconcile loop.
+func EvaluateIngress(dashboard *homerv1alpha1.Dashboard, ingress *networkingv1.Ingress) homer.IngressEvaluation {
+	return homer.EvaluateIngress(*ingress, DiscoveryOptions(dashboard, logr.Discard()), time.Now())
+}
+
+// shouldIncludeIngress checks if the Dashboard discovers the ingress, see homer.EvaluateIngress.
+// Visibility windows are left out, so changes to a hidden ingress still reach the Dashboard.
+func shouldIncludeIngress(dashboard *homerv1alpha1.Dashboard, ingress *networkingv1.Ingress) bool {
+	return homer.EvaluateIngress(*ingress, DiscoveryOptions(dashboard, logr.Discard()), time.Time{}).Included()
+}
+
+// ingressItemsChanged passes the ingress updates that can change the items built from
+// the ingress: changes of its spec, labels, or annotations, and changes of its load
+// balancer status when an item may take its host from there. Other status updates,
+// such as load balancer address churn of ingresses with plain rule hosts, are dropped.
+var ingressItemsChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldIngress, ok := e.ObjectOld.(*networkingv1.Ingress)
+		newIngress, ok2 := e.ObjectNew.(*networkingv1.Ingress)
+		if !ok || !ok2 {
+			return true
+		}
+		if oldIngress.Generation != newIngress.Generation ||
+			!equality.Semantic.DeepEqual(oldIngress.Labels, newIngress.Labels) ||
+			!equality.Semantic.DeepEqual(oldIngress.Annotations, newIngress.Annotations) {
+			return true
+		}
+		return usesStatusHost(newIngress) &&
+			!equality.Semantic.DeepEqual(oldIngress.Status.LoadBalancer, newIngress.Status.LoadBalancer)
+	},
+}
+
+// usesStatusHost reports whether an item of the ingress may take its host from the load
+// balancer status: the ingress has no rules, or a rule with an empty or wildcard host.
+func usesStatusHost(ingress *networkingv1.Ingress) bool {
+	if len(ingress.Spec.Rules) == 0 {
+		return true
+	}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host == "" || strings.HasPrefix(rule.Host, "*.") {
+			return true
+		}
+	}
+	return false
+}
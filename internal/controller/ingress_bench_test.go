@@ -0,0 +1,89 @@
+/*
+Copyright 2024 RajSingh.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	homerv1alpha1 "github.com/rajsinghtech/homer-operator.git/api/v1alpha1"
+)
+
+// BenchmarkIngressMatching compares matching every ingress against every Dashboard with
+// matching only the candidates from ingressClassIndex, using the client-go indexer
+// that backs the manager's cache, for 1000 ingresses and 50 Dashboards.
+func BenchmarkIngressMatching(b *testing.B) {
+	const ingresses, dashboards = 1000, 50
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+		ingressClassIndex: func(obj interface{}) ([]string, error) {
+			return indexIngressClass(obj.(client.Object)), nil
+		},
+	})
+	for i := 0; i < ingresses; i++ {
+		className := fmt.Sprintf("class-%d", i%dashboards)
+		ingress := &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("app-%d", i), Namespace: "default"},
+			Spec:       networkingv1.IngressSpec{IngressClassName: &className},
+		}
+		if err := indexer.Add(ingress); err != nil {
+			b.Fatal(err)
+		}
+	}
+	dashboardList := make([]*homerv1alpha1.Dashboard, dashboards)
+	for i := range dashboardList {
+		dashboardList[i] = &homerv1alpha1.Dashboard{Spec: homerv1alpha1.DashboardSpec{
+			IngressClassName: fmt.Sprintf("class-%d", i),
+		}}
+	}
+	match := func(dashboard *homerv1alpha1.Dashboard, candidates []interface{}) int {
+		matched := 0
+		for _, obj := range candidates {
+			if shouldIncludeIngress(dashboard, obj.(*networkingv1.Ingress)) {
+				matched++
+			}
+		}
+		return matched
+	}
+
+	b.Run("all ingresses", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for _, dashboard := range dashboardList {
+				if match(dashboard, indexer.List()) != ingresses/dashboards {
+					b.Fatal("unexpected number of matches")
+				}
+			}
+		}
+	})
+	b.Run("indexed candidates", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for _, dashboard := range dashboardList {
+				candidates, err := indexer.ByIndex(ingressClassIndex, dashboard.Spec.IngressClassName)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if match(dashboard, candidates) != ingresses/dashboards {
+					b.Fatal("unexpected number of matches")
+				}
+			}
+		}
+	})
+}
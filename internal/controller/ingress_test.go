@@ -0,0 +1,181 @@
+/*
+Copyright 2024 RajSingh.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	homerv1alpha1 "github.com/rajsinghtech/homer-operator.git/api/v1alpha1"
+)
+
+var _ = Describe("Ingress discovery", func() {
+	className := func(name string) *string { return &name }
+
+	Context("When matching an ingress against a dashboard", func() {
+		It("should include ingresses of any class when no class is set", func() {
+			dashboard := &homerv1alpha1.Dashboard{}
+			ingress := &networkingv1.Ingress{Spec: networkingv1.IngressSpec{IngressClassName: className("nginx")}}
+			Expect(shouldIncludeIngress(dashboard, ingress)).To(BeTrue())
+		})
+
+		It("should filter ingresses by spec.ingressClassName", func() {
+			dashboard := &homerv1alpha1.Dashboard{Spec: homerv1alpha1.DashboardSpec{IngressClassName: "traefik"}}
+			Expect(shouldIncludeIngress(dashboard, &networkingv1.Ingress{
+				Spec: networkingv1.IngressSpec{IngressClassName: className("traefik")},
+			})).To(BeTrue())
+			Expect(shouldIncludeIngress(dashboard, &networkingv1.Ingress{
+				Spec: networkingv1.IngressSpec{IngressClassName: className("nginx")},
+			})).To(BeFalse())
+			Expect(shouldIncludeIngress(dashboard, &networkingv1.Ingress{})).To(BeFalse())
+		})
+
+		It("should fall back to the legacy ingress class annotation", func() {
+			dashboard := &homerv1alpha1.Dashboard{Spec: homerv1alpha1.DashboardSpec{IngressClassName: "traefik"}}
+			ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{"kubernetes.io/ingress.class": "traefik"},
+			}}
+			Expect(shouldIncludeIngress(dashboard, ingress)).To(BeTrue())
+		})
+
+		It("should filter ingresses by spec.domainFilters", func() {
+			dashboard := &homerv1alpha1.Dashboard{
+				Spec: homerv1alpha1.DashboardSpec{DomainFilters: []string{"*.staging.example.com"}},
+			}
+			withHost := func(host string) *networkingv1.Ingress {
+				return &networkingv1.Ingress{Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: host}}}}
+			}
+			Expect(shouldIncludeIngress(dashboard, withHost("app.staging.example.com"))).To(BeTrue())
+			Expect(shouldIncludeIngress(dashboard, withHost("app.example.com"))).To(BeFalse())
+			Expect(EvaluateIngress(dashboard, withHost("example.com")).Reason()).To(Equal("no host matches the domain filters"))
+		})
+
+		It("should skip cert-manager ACME solver ingresses unless asked not to", func() {
+			solver := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+				Name:   "cm-acme-http-solver-x7k2p",
+				Labels: map[string]string{"acme.cert-manager.io/http01-solver": "true"},
+			}}
+			dashboard := &homerv1alpha1.Dashboard{}
+			Expect(shouldIncludeIngress(dashboard, solver)).To(BeFalse())
+			Expect(EvaluateIngress(dashboard, solver).Reason()).To(Equal("cert-manager ACME solver ingress"))
+			dashboard.Spec.IncludeACMESolvers = true
+			Expect(shouldIncludeIngress(dashboard, solver)).To(BeTrue())
+		})
+	})
+
+	Context("When listing the candidate ingresses of a dashboard", func() {
+		It("should only list the ingresses of the dashboard's class from the index", func() {
+			ingress := func(name string, class *string, annotations map[string]string) *networkingv1.Ingress {
+				return &networkingv1.Ingress{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "apps", Annotations: annotations},
+					Spec:       networkingv1.IngressSpec{IngressClassName: class},
+				}
+			}
+			reconciler := newFakeReconciler(
+				ingress("traefik", className("traefik"), nil),
+				ingress("legacy", nil, map[string]string{"kubernetes.io/ingress.class": "traefik"}),
+				ingress("nginx", className("nginx"), nil),
+				ingress("classless", nil, nil),
+			)
+			names := func(dashboard *homerv1alpha1.Dashboard) []string {
+				ingressList, err := reconciler.listCandidateIngresses(context.Background(), dashboard)
+				Expect(err).NotTo(HaveOccurred())
+				var names []string
+				for _, ingress := range ingressList.Items {
+					names = append(names, ingress.Name)
+				}
+				return names
+			}
+			classed := &homerv1alpha1.Dashboard{Spec: homerv1alpha1.DashboardSpec{IngressClassName: "traefik"}}
+			Expect(names(classed)).To(ConsistOf("traefik", "legacy"))
+			Expect(names(&homerv1alpha1.Dashboard{})).To(ConsistOf("traefik", "legacy", "nginx", "classless"))
+		})
+	})
+
+	Context("When mapping an ingress event to dashboards", func() {
+		It("should only enqueue the dashboards that may discover the ingress", func() {
+			dashboard := func(name string, spec homerv1alpha1.DashboardSpec) *homerv1alpha1.Dashboard {
+				return &homerv1alpha1.Dashboard{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}, Spec: spec}
+			}
+			ingress := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "grafana", Namespace: "monitoring"},
+				Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: "grafana.example.com"}}},
+			}
+			reconciler := newFakeReconciler(
+				dashboard("all", homerv1alpha1.DashboardSpec{}),
+				dashboard("paused", homerv1alpha1.DashboardSpec{Paused: true}),
+				dashboard("classed", homerv1alpha1.DashboardSpec{IngressClassName: "traefik"}),
+				dashboard("domains", homerv1alpha1.DashboardSpec{DomainFilters: []string{"example.org"}}),
+				dashboard("names", homerv1alpha1.DashboardSpec{ExcludeNamePatterns: []string{"graf*"}}),
+			)
+			names := func() []string {
+				var names []string
+				for _, request := range reconciler.findDashboardsForIngress(context.Background(), ingress) {
+					names = append(names, request.Name)
+				}
+				return names
+			}
+			Expect(names()).To(ConsistOf("all"))
+			ingress.Spec.IngressClassName = className("traefik")
+			Expect(names()).To(ConsistOf("all", "classed"))
+		})
+	})
+})
+
+var _ = Describe("Ingress update filtering", func() {
+	withLoadBalancer := func(ingress *networkingv1.Ingress, hostname string) *networkingv1.Ingress {
+		updated := ingress.DeepCopy()
+		updated.Status.LoadBalancer.Ingress = []networkingv1.IngressLoadBalancerIngress{{Hostname: hostname}}
+		return updated
+	}
+	changed := func(oldIngress, newIngress *networkingv1.Ingress) bool {
+		return ingressItemsChanged.Update(event.UpdateEvent{ObjectOld: oldIngress, ObjectNew: newIngress})
+	}
+
+	It("should ignore load balancer changes of ingresses with plain rule hosts", func() {
+		ingress := &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 1},
+			Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: "app.example.com"}}},
+		}
+		Expect(changed(ingress, withLoadBalancer(ingress, "lb.example.net"))).To(BeFalse())
+
+		updated := ingress.DeepCopy()
+		updated.Annotations = map[string]string{"item.homer.rajsingh.info/name": "App"}
+		Expect(changed(ingress, updated)).To(BeTrue())
+		updated = ingress.DeepCopy()
+		updated.Generation = 2
+		Expect(changed(ingress, updated)).To(BeTrue())
+	})
+
+	It("should pass load balancer changes of ingresses taking their host from there", func() {
+		wildcard := &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 1},
+			Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: "*.example.com"}}},
+		}
+		Expect(changed(wildcard, withLoadBalancer(wildcard, "lb.example.net"))).To(BeTrue())
+		defaultBackend := &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 1},
+			Spec:       networkingv1.IngressSpec{DefaultBackend: &networkingv1.IngressBackend{}},
+		}
+		Expect(changed(defaultBackend, withLoadBalancer(defaultBackend, "lb.example.net"))).To(BeTrue())
+	})
+})
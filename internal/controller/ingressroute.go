@@ -0,0 +1,79 @@
+/*
+Copyright 2024 RajSingh.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	homerv1alpha1 "github.com/rajsinghtech/homer-operator.git/api/v1alpha1"
+	homer "github.com/rajsinghtech/homer-operator.git/pkg/homer"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+//+kubebuilder:rbac:groups=traefik.io,resources=ingressroutes,verbs=get;list;watch
+
+// ingressRouteGVK is the Traefik IngressRoute kind. It is handled as unstructured
+// objects, so the operator does not depend on the Traefik API types.
+var ingressRouteGVK = schema.GroupVersionKind{Group: "traefik.io", Version: "v1alpha1", Kind: "IngressRoute"}
+
+// newIngressRoute returns an empty unstructured Traefik IngressRoute to watch.
+func newIngressRoute() *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(ingressRouteGVK)
+	return route
+}
+
+// addIngressRoutes adds the Traefik IngressRoutes to config when the operator runs with
+// Traefik discovery enabled.
+func (r *DashboardReconciler) addIngressRoutes(ctx context.Context, dashboard *homerv1alpha1.Dashboard,
+	config *homer.HomerConfig, options homer.DiscoveryOptions) error {
+	if !r.EnableTraefik {
+		return nil
+	}
+	routeList := &unstructured.UnstructuredList{}
+	routeList.SetGroupVersionKind(ingressRouteGVK.GroupVersion().WithKind(ingressRouteGVK.Kind + "List"))
+	if err := r.List(ctx, routeList); err != nil {
+		return fmt.Errorf("unable to list IngressRoutes: %w", err)
+	}
+	for _, object := range routeList.Items {
+		homer.UpdateHomerConfigIngressRoute(config, ingressRouteFromUnstructured(object), options)
+	}
+	return nil
+}
+
+// ingressRouteFromUnstructured reads the match expressions and TLS block of an IngressRoute.
+func ingressRouteFromUnstructured(object unstructured.Unstructured) homer.IngressRoute {
+	route := homer.IngressRoute{
+		Name:        object.GetName(),
+		Namespace:   object.GetNamespace(),
+		Labels:      object.GetLabels(),
+		Annotations: object.GetAnnotations(),
+		LastUpdate:  homer.LastUpdate(&object),
+	}
+	routes, _, _ := unstructured.NestedSlice(object.Object, "spec", "routes")
+	for _, entry := range routes {
+		if fields, ok := entry.(map[string]interface{}); ok {
+			if match, ok := fields["match"].(string); ok {
+				route.Matches = append(route.Matches, match)
+			}
+		}
+	}
+	_, route.TLS, _ = unstructured.NestedFieldNoCopy(object.Object, "spec", "tls")
+	return route
+}
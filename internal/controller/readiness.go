@@ -0,0 +1,63 @@
+/*
+Copyright 2024 RajSingh.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	homerv1alpha1 "github.com/rajsinghtech/homer-operator.git/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// readyzTimeout bounds how long a readiness probe waits for the informer caches.
+const readyzTimeout = time.Second
+
+// ReadyzCheck reports the operator ready once waitForCacheSync, e.g. the manager cache's
+// WaitForCacheSync, reports the informer caches synced and, after elected is closed, e.g.
+// the manager's Elected channel, a reconcile completed. A replica that is not the leader
+// never reconciles, so it only needs synced caches, as does a leader with no Dashboards.
+func (r *DashboardReconciler) ReadyzCheck(waitForCacheSync func(context.Context) bool,
+	elected <-chan struct{}) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), readyzTimeout)
+		defer cancel()
+		if !waitForCacheSync(ctx) {
+			return errors.New("informer caches have not synced")
+		}
+		select {
+		case <-elected:
+		default:
+			return nil
+		}
+		if r.reconciled.Load() {
+			return nil
+		}
+		var dashboardList homerv1alpha1.DashboardList
+		if err := r.List(ctx, &dashboardList); err != nil {
+			return err
+		}
+		for _, dashboard := range dashboardList.Items {
+			if r.ownsInstance(&dashboard) {
+				return errors.New("no Dashboard reconcile has completed yet")
+			}
+		}
+		return nil
+	}
+}
@@ -1,10 +1,20 @@
 package homer
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
 	"os"
 	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
+	"github.com/go-logr/logr"
 	yaml "gopkg.in/yaml.v2"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -14,57 +24,105 @@ import (
 )
 
 type HomerConfig struct {
-	Title    string        `json:"title,omitempty"`
-	Subtitle string        `json:"subtitle,omitempty"`
-	Logo     string        `json:"logo,omitempty"`
-	Header   string        `json:"header,omitempty"`
-	Services []Service     `json:"services,omitempty"`
-	Footer   string        `json:"footer,omitempty"`
-	Defaults DefaultConfig `json:"defaults,omitempty"`
-	Links    []Link        `json:"links,omitempty"`
+	// Title and Subtitle may be Go templates over TitleData, e.g. "Apps on {{ .ClusterName }}".
+	Title    string        `json:"title,omitempty" yaml:"title"`
+	Subtitle string        `json:"subtitle,omitempty" yaml:"subtitle"`
+	Logo     string        `json:"logo,omitempty" yaml:"logo"`
+	Header   string        `json:"header,omitempty" yaml:"header"`
+	Services []Service     `json:"services,omitempty" yaml:"services"`
+	Footer   string        `json:"footer,omitempty" yaml:"footer"`
+	Defaults DefaultConfig `json:"defaults,omitempty" yaml:"defaults"`
+	Links    []Link        `json:"links,omitempty" yaml:"links"`
+	Proxy    *ProxyConfig  `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+	Theme    string        `json:"theme,omitempty" yaml:"theme,omitempty"`
+	Colors   *ColorConfig  `json:"colors,omitempty" yaml:"colors,omitempty"`
+	// ExternalConfig is the URL Homer loads its config from instead of this one. When
+	// set, only this URL and the discovered items are written to config.yml.
+	ExternalConfig string        `json:"externalConfig,omitempty" yaml:"externalConfig,omitempty"`
+	Hotkey         *HotkeyConfig `json:"hotkey,omitempty" yaml:"hotkey,omitempty"`
+	// ConnectivityCheck turns Homer's warning when the dashboard loses its connection on
+	// or off. An explicit false is written to config.yml; unset leaves Homer's default.
+	ConnectivityCheck *bool `json:"connectivityCheck,omitempty" yaml:"connectivityCheck,omitempty"`
+	// Stylesheet lists extra CSS files Homer loads, as URLs or as paths such as
+	// assets/custom/custom.css.
+	Stylesheet []string `json:"stylesheet,omitempty" yaml:"stylesheet,omitempty"`
+}
+
+// HotkeyConfig sets the keyboard shortcuts of the dashboard.
+type HotkeyConfig struct {
+	// Search is the key focusing the search box, e.g. "/" or "Shift". Homer defaults to "/".
+	Search string `json:"search,omitempty" yaml:"search,omitempty"`
+}
+
+// externalHomerConfig is the config.yml written when HomerConfig.ExternalConfig is set.
+type externalHomerConfig struct {
+	ExternalConfig string    `yaml:"externalConfig"`
+	Services       []Service `yaml:"services,omitempty"`
 }
 
 type ProxyConfig struct {
-	UseCredentials bool `json:"useCredentials,omitempty"`
+	UseCredentials bool              `json:"useCredentials,omitempty" yaml:"useCredentials,omitempty"`
+	Headers        map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
 }
 
 type DefaultConfig struct {
-	Layout     string `json:"layout,omitempty"`
-	ColorTheme string `json:"colorTheme,omitempty"`
+	Layout     string `json:"layout,omitempty" yaml:"layout"`
+	ColorTheme string `json:"colorTheme,omitempty" yaml:"colorTheme"`
 }
 
 type Service struct {
-	Name  string `json:"name,omitempty"`
-	Icon  string `json:"icon,omitempty"`
-	Logo  string `json:"logo,omitempty"`
-	Items []Item `json:"items,omitempty"`
+	Name  string `json:"name,omitempty" yaml:"name"`
+	Icon  string `json:"icon,omitempty" yaml:"icon"`
+	Logo  string `json:"logo,omitempty" yaml:"logo"`
+	Items []Item `json:"items,omitempty" yaml:"items"`
+	// Layout overrides the dashboard layout for this group, either "columns" or "list".
+	Layout string `json:"layout,omitempty" yaml:"layout,omitempty"`
+	// Frozen keeps the service to the items defined in the Dashboard; discovered
+	// items are never added to it. It is not written to config.yml.
+	Frozen bool `json:"frozen,omitempty" yaml:"-"`
 }
 
 type Item struct {
-	Name         string `json:"name,omitempty"`
-	Logo         string `json:"logo,omitempty"`
-	Subtitle     string `json:"subtitle,omitempty"`
-	Tag          string `json:"tag,omitempty"`
-	Keywords     string `json:"keywords,omitempty"`
-	Url          string `json:"url,omitempty"`
-	Target       string `json:"target,omitempty"`
-	Tagstyle     string `json:"tagstyle,omitempty"`
-	Type         string `json:"type,omitempty"`
-	Class        string `json:"class,omitempty"`
-	Background   string `json:"background,omitempty"`
-	Apikey       string `json:"apikey,omitempty"`
-	Node      	 string `json:"node,omitempty"`
-	Legacyapi    string `json:"legacyApi,omitempty"`
-	Librarytype  string `json:"libraryType,omitempty"`
-	Warningvalue string `json:"warning_value,omitempty"`
-	Dangervalue  string `json:"danger_value,omitempty"`
+	Name         string `json:"name,omitempty" yaml:"name"`
+	Logo         string `json:"logo,omitempty" yaml:"logo"`
+	Icon         string `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Subtitle     string `json:"subtitle,omitempty" yaml:"subtitle"`
+	Tag          string `json:"tag,omitempty" yaml:"tag"`
+	Keywords     string `json:"keywords,omitempty" yaml:"keywords"`
+	Url          string `json:"url,omitempty" yaml:"url"`
+	Target       string `json:"target,omitempty" yaml:"target"`
+	Tagstyle     string `json:"tagstyle,omitempty" yaml:"tagstyle"`
+	Type         string `json:"type,omitempty" yaml:"type"`
+	Class        string `json:"class,omitempty" yaml:"class"`
+	Background   string `json:"background,omitempty" yaml:"background"`
+	Apikey       string `json:"apikey,omitempty" yaml:"apikey"`
+	Node         string `json:"node,omitempty" yaml:"node"`
+	Legacyapi    string `json:"legacyApi,omitempty" yaml:"legacyApi"`
+	Librarytype  string `json:"libraryType,omitempty" yaml:"libraryType"`
+	Warningvalue string `json:"warning_value,omitempty" yaml:"warning_value"`
+	Dangervalue  string `json:"danger_value,omitempty" yaml:"danger_value"`
+	Endpoint     string `json:"endpoint,omitempty" yaml:"endpoint"`
+	// Source identifies the resource an item was discovered from. It is empty for
+	// items defined in the Dashboard and never written to config.yml.
+	Source string `json:"-" yaml:"-"`
+	// LastUpdate is when the source of a discovered item was last written. It is zero
+	// for items defined in the Dashboard and never written to config.yml.
+	LastUpdate time.Time `json:"-" yaml:"-"`
+	// After and Before name a sibling in the service group the item is placed right
+	// after or somewhere before by OrderServiceItems.
+	After  string `json:"-" yaml:"-"`
+	Before string `json:"-" yaml:"-"`
+	// VisibleFrom and VisibleUntil bound the time the item is shown in, when set. Items
+	// outside their window are removed by ApplyVisibilityWindows.
+	VisibleFrom  time.Time `json:"-" yaml:"-"`
+	VisibleUntil time.Time `json:"-" yaml:"-"`
 }
 
 type Link struct {
-	Name   string `json:"name,omitempty"`
-	Icon   string `json:"icon,omitempty"`
-	Url    string `json:"url,omitempty"`
-	Target string `json:"target,omitempty"`
+	Name   string `json:"name,omitempty" yaml:"name"`
+	Icon   string `json:"icon,omitempty" yaml:"icon"`
+	Url    string `json:"url,omitempty" yaml:"url"`
+	Target string `json:"target,omitempty" yaml:"target"`
 }
 
 // LoadConfigFromFile loads HomerConfig from a YAML file.
@@ -82,39 +140,316 @@ func LoadConfigFromFile(filename string) (*HomerConfig, error) {
 	return &config, nil
 }
 
-func CreateConfigMap(config HomerConfig, name string, namespace string, ingresses networkingv1.IngressList) corev1.ConfigMap {
-	UpdateHomerConfig(&config, ingresses)
-	objYAML, err := yaml.Marshal(config)
+// RenderOptions controls how a HomerConfig is written to config.yml.
+type RenderOptions struct {
+	// KeyOverrides renames keys anywhere in the generated YAML, for Homer fields whose
+	// expected spelling differs from the one the operator emits, e.g. {"apikey": "apiKey"}.
+	KeyOverrides map[string]string
+	// Minimal leaves out empty values and the defaults block values equal to Homer's
+	// defaults, so config.yml only holds what was actually configured.
+	Minimal bool
+	// ConfigKey is the ConfigMap key the config is stored under. Empty uses DefaultConfigKey.
+	ConfigKey string
+}
+
+// ConfigChecksumAnnotation holds the ConfigChecksum of the rendered config on the Homer
+// ConfigMap, so tooling can tell whether the config changed without comparing it.
+const ConfigChecksumAnnotation = "homer.rajsingh.info/config-checksum"
+
+// ManualOverrideAnnotation set to "true" on the Homer ConfigMap stops the operator from
+// regenerating it, so a hand-edited config survives until the annotation is removed.
+const ManualOverrideAnnotation = "homer.rajsingh.info/manual-override"
+
+// ConfigChecksum returns the hex encoded SHA-256 of a rendered config.
+func ConfigChecksum(config []byte) string {
+	sum := sha256.Sum256(config)
+	return hex.EncodeToString(sum[:])
+}
+
+// DefaultConfigKey is the ConfigMap key, and so the file name under /www/assets, Homer
+// loads its config from.
+const DefaultConfigKey = "config.yml"
+
+// configKey returns the ConfigMap key to store the config under.
+func (o RenderOptions) configKey() string {
+	if o.ConfigKey == "" {
+		return DefaultConfigKey
+	}
+	return o.ConfigKey
+}
+
+// homerDefaults are the values Homer uses for a key that is not set.
+var homerDefaults = map[string]string{
+	"layout":     "columns",
+	"colorTheme": "auto",
+}
+
+// InstanceLabel marks the resources created by an operator started with a
+// non-empty --instance-name, so several operators can share a cluster.
+const InstanceLabel = "homer.rajsingh.info/instance"
+
+// resourceLabels returns the labels of the resources created for a Dashboard.
+func resourceLabels(name string, instance string) map[string]string {
+	labels := map[string]string{
+		"managed-by":                         "homer-operator",
+		"dashboard.homer.rajsingh.info/name": name,
+	}
+	if instance != "" {
+		labels[InstanceLabel] = instance
+	}
+	return labels
+}
+
+// CreateConfigMap renders config into the ConfigMap served by the Dashboard's Homer
+// Deployment. Discovered items are expected to be added with UpdateHomerConfig first.
+// The footer, which Homer renders as HTML, is sanitized.
+func CreateConfigMap(config HomerConfig, name, namespace, instance string, options RenderOptions) corev1.ConfigMap {
+	config.Footer = sanitizeHTML(config.Footer)
+	objYAML, err := marshalHomerConfigToYAML(config, options)
 	if err != nil {
 		return corev1.ConfigMap{}
 	}
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-			Labels: map[string]string{
-				"managed-by":                         "homer-operator",
-				"dashboard.homer.rajsingh.info/name": name,
-			},
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      resourceLabels(name, instance),
+			Annotations: map[string]string{ConfigChecksumAnnotation: ConfigChecksum(objYAML)},
 		},
 		Data: map[string]string{
-			"config.yml": string(objYAML),
+			options.configKey(): string(objYAML),
 		},
 	}
 	return *cm
 }
 
-func CreateDeployment(name string, namespace string) appsv1.Deployment {
+// AssetsMountPath is where the files of the assets ConfigMap are served from in the Homer container.
+const AssetsMountPath = "/www/assets/custom"
+
+// AssetConfigMapName returns the name of the assets ConfigMap of a Dashboard.
+func AssetConfigMapName(name string) string {
+	return name + "-homer-assets"
+}
+
+// CreateAssetConfigMap stores files, such as logos, and stylesheets in a ConfigMap that
+// is mounted at AssetsMountPath by AddAssetsVolume.
+func CreateAssetConfigMap(files map[string][]byte, stylesheets map[string]string,
+	name, namespace, instance string) corev1.ConfigMap {
+	return corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      AssetConfigMapName(name),
+			Namespace: namespace,
+			Labels:    resourceLabels(name, instance),
+		},
+		Data:       stylesheets,
+		BinaryData: files,
+	}
+}
+
+// AssetPath returns the path, relative to the Homer root, a file of the assets
+// ConfigMap is served at.
+func AssetPath(file string) string {
+	return "assets/custom/" + file
+}
+
+var stylesheetNameRegex = regexp.MustCompile(`^[-_a-zA-Z0-9][-._a-zA-Z0-9]*\.css$`)
+
+// StylesheetPaths returns the asset paths of stylesheets, sorted by file name, for
+// HomerConfig.Stylesheet. A name that is not a plain .css file name, or that is also
+// used by one of files, is an error.
+func StylesheetPaths(stylesheets map[string]string, files map[string][]byte) ([]string, error) {
+	names := make([]string, 0, len(stylesheets))
+	for name := range stylesheets {
+		if !stylesheetNameRegex.MatchString(name) {
+			return nil, fmt.Errorf("stylesheet %q is not a .css file name", name)
+		}
+		if _, ok := files[name]; ok {
+			return nil, fmt.Errorf("stylesheet %q is also an asset file", name)
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		paths = append(paths, AssetPath(name))
+	}
+	return paths, nil
+}
+
+// AddAssetsVolume mounts the assets ConfigMap of the Dashboard into the Homer container.
+func AddAssetsVolume(deployment *appsv1.Deployment, name string) {
+	pod := &deployment.Spec.Template.Spec
+	pod.Volumes = append(pod.Volumes, corev1.Volume{
+		Name: "assets-volume",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: AssetConfigMapName(name)},
+			},
+		},
+	})
+	pod.Containers[0].VolumeMounts = append(pod.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "assets-volume",
+		MountPath: AssetsMountPath,
+	})
+}
+
+// AddPages serves the config of each ConfigMap in pages, keyed by ConfigMap name with the
+// config key as value, as the additional Homer page assets/<name>.yml, opened as #<name>.
+// The pages are projected into the config volume next to the Dashboard's own config.
+func AddPages(deployment *appsv1.Deployment, pages map[string]string) {
+	names := make([]string, 0, len(pages))
+	for name := range pages {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	optional := true
+	volumes := deployment.Spec.Template.Spec.Volumes
+	for i := range volumes {
+		if volumes[i].Name != "config-volume" || volumes[i].ConfigMap == nil {
+			continue
+		}
+		sources := []corev1.VolumeProjection{{ConfigMap: &corev1.ConfigMapProjection{
+			LocalObjectReference: volumes[i].ConfigMap.LocalObjectReference,
+		}}}
+		for _, name := range names {
+			sources = append(sources, corev1.VolumeProjection{ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: name},
+				Items:                []corev1.KeyToPath{{Key: pages[name], Path: name + ".yml"}},
+				Optional:             &optional,
+			}})
+		}
+		volumes[i].VolumeSource = corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: sources}}
+	}
+}
+
+// marshalHomerConfigToYAML marshals config using the Homer key spelling from the
+// struct tags and then applies the key overrides of options. A config with an
+// ExternalConfig is reduced to that URL and its discovered items.
+func marshalHomerConfigToYAML(config HomerConfig, options RenderOptions) ([]byte, error) {
+	var document interface{} = config
+	if config.ExternalConfig != "" {
+		document = externalHomerConfig{
+			ExternalConfig: config.ExternalConfig,
+			Services:       discoveredServices(config.Services),
+		}
+	}
+	objYAML, err := yaml.Marshal(document)
+	if err != nil || (len(options.KeyOverrides) == 0 && !options.Minimal) {
+		return objYAML, err
+	}
+	var tree yaml.MapSlice
+	if err := yaml.Unmarshal(objYAML, &tree); err != nil {
+		return nil, err
+	}
+	var result interface{} = tree
+	if options.Minimal {
+		pruneDefaults(tree)
+		if result = pruneYAML(tree); result == nil {
+			result = yaml.MapSlice{}
+		}
+	}
+	return yaml.Marshal(renameYAMLKeys(result, options.KeyOverrides))
+}
+
+// pruneDefaults drops the values equal to their homerDefaults entry from the top-level
+// defaults block of a decoded config. Values elsewhere are kept, since e.g. the layout
+// of a service group overrides defaults.layout.
+func pruneDefaults(tree yaml.MapSlice) {
+	for i, entry := range tree {
+		defaults, ok := entry.Value.(yaml.MapSlice)
+		if entry.Key != "defaults" || !ok {
+			continue
+		}
+		pruned := yaml.MapSlice{}
+		for _, setting := range defaults {
+			if key, ok := setting.Key.(string); !ok || homerDefaults[key] != setting.Value {
+				pruned = append(pruned, setting)
+			}
+		}
+		tree[i].Value = pruned
+	}
+}
+
+// pruneYAML drops empty values from a decoded YAML document. It returns nil when
+// nothing is left of node.
+func pruneYAML(node interface{}) interface{} {
+	switch value := node.(type) {
+	case yaml.MapSlice:
+		pruned := yaml.MapSlice{}
+		for _, entry := range value {
+			if entry.Value = pruneYAML(entry.Value); entry.Value != nil {
+				pruned = append(pruned, entry)
+			}
+		}
+		if len(pruned) == 0 {
+			return nil
+		}
+		return pruned
+	case []interface{}:
+		var pruned []interface{}
+		for _, element := range value {
+			if element = pruneYAML(element); element != nil {
+				pruned = append(pruned, element)
+			}
+		}
+		if len(pruned) == 0 {
+			return nil
+		}
+		return pruned
+	case string:
+		if value == "" {
+			return nil
+		}
+	}
+	return node
+}
+
+// discoveredServices returns the services holding discovered items, with only those items.
+func discoveredServices(services []Service) []Service {
+	var discovered []Service
+	for _, service := range services {
+		var items []Item
+		for _, item := range service.Items {
+			if item.Source != "" {
+				items = append(items, item)
+			}
+		}
+		if len(items) > 0 {
+			service.Items = items
+			discovered = append(discovered, service)
+		}
+	}
+	return discovered
+}
+
+// renameYAMLKeys walks a decoded YAML document and renames every map key found in overrides.
+func renameYAMLKeys(node interface{}, overrides map[string]string) interface{} {
+	switch value := node.(type) {
+	case yaml.MapSlice:
+		for i := range value {
+			if key, ok := value[i].Key.(string); ok {
+				if renamed, ok := overrides[key]; ok {
+					value[i].Key = renamed
+				}
+			}
+			value[i].Value = renameYAMLKeys(value[i].Value, overrides)
+		}
+	case []interface{}:
+		for i := range value {
+			value[i] = renameYAMLKeys(value[i], overrides)
+		}
+	}
+	return node
+}
+
+func CreateDeployment(name string, namespace string, instance string) appsv1.Deployment {
 	var replicas int32 = 1
 	image := "b4bz/homer"
 	d := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels: map[string]string{
-				"managed-by":                         "homer-operator",
-				"dashboard.homer.rajsingh.info/name": name,
-			},
+			Labels:    resourceLabels(name, instance),
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &replicas,
@@ -166,15 +501,12 @@ func CreateDeployment(name string, namespace string) appsv1.Deployment {
 	return *d
 }
 
-func CreateService(name string, namespace string) corev1.Service {
+func CreateService(name string, namespace string, instance string) corev1.Service {
 	s := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels: map[string]string{
-				"managed-by":                         "homer-operator",
-				"dashboard.homer.rajsingh.info/name": name,
-			},
+			Labels:    resourceLabels(name, instance),
 		},
 		Spec: corev1.ServiceSpec{
 			Selector: map[string]string{
@@ -190,42 +522,142 @@ func CreateService(name string, namespace string) corev1.Service {
 	}
 	return *s
 }
-func UpdateHomerConfig(config *HomerConfig, ingresses networkingv1.IngressList) error {
+
+// Default logos of discovered items and the service groups created for them.
+const (
+	kubernetesIconsURL = "https://raw.githubusercontent.com/kubernetes/community/master/icons/png/resources/labeled/"
+	IngressIconURL     = kubernetesIconsURL + "ing-128.png"
+	NamespaceIconURL   = kubernetesIconsURL + "ns-128.png"
+)
+
+// DiscoveryOptions controls how discovered ingresses are turned into items.
+type DiscoveryOptions struct {
+	// AllowedItemFields restricts the item fields annotations may set. Empty allows all.
+	AllowedItemFields []string
+	// AllowedServiceFields restricts the service fields annotations may set. Empty allows all.
+	AllowedServiceFields []string
+	// Strict drops fields that are not allowed instead of only logging them.
+	Strict bool
+	// DomainFilters limits discovered items to hosts matching one of the filters,
+	// see utils.MatchesDomainFilter. Empty includes every host.
+	DomainFilters []string
+	// ExcludeHostPatterns drops the items of hosts matching one of the patterns, see
+	// utils.MatchesDomainFilter.
+	ExcludeHostPatterns []string
+	// ExcludeNamePatterns skips ingresses whose name matches one of the patterns, see
+	// utils.MatchesNamePatterns.
+	ExcludeNamePatterns []string
+	// IngressClassName skips ingresses of other classes. Empty includes every class.
+	IngressClassName string
+	// IncludeACMESolvers keeps the ingresses cert-manager creates for HTTP-01 challenges.
+	IncludeACMESolvers bool
+	// IngressLogo replaces IngressIconURL as the logo of items discovered from ingresses.
+	IngressLogo string
+	// ServiceLogo replaces NamespaceIconURL as the logo of service groups created for discovered items.
+	ServiceLogo string
+	// ServiceGroup, when set, is the service group of discovered items instead of their
+	// namespace.
+	ServiceGroup string
+	// ItemNameTemplate is a text/template naming the items of ingresses with several
+	// hosts, e.g. `{{ trimSuffix .Host ".example.com" }}`. It sees .Name, .Namespace
+	// and .Host. Empty names every item after the ingress.
+	ItemNameTemplate string
+	// DefaultScheme, when set, is the URL scheme of discovered items instead of
+	// guessing it from the TLS block of the ingress.
+	DefaultScheme string
+	// PreferStatusHostnames replaces empty and wildcard rule hosts by the hostname or IP
+	// the load balancer of the ingress reports.
+	PreferStatusHostnames bool
+	// KeywordLabels lists label keys whose values are added to the keywords of discovered
+	// items, together with their namespace and ClusterName. Empty adds no keywords.
+	KeywordLabels []string
+	// ClusterName is added to the keywords of discovered items when KeywordLabels is set.
+	ClusterName string
+	// Logger receives warnings about the discovered resources.
+	Logger logr.Logger
+}
+
+// UpdateHomerConfig adds an item for every rule of every ingress that EvaluateIngress
+// includes to config. The services of config are copied first, so the discovered items
+// never leak into the slices of the HomerConfig it was copied from.
+func UpdateHomerConfig(config *HomerConfig, ingresses networkingv1.IngressList, options DiscoveryOptions) error {
+	nameTemplate, err := parseItemNameTemplate(options.ItemNameTemplate)
+	if err != nil {
+		return err
+	}
+	config.Services = copyServices(config.Services)
+	config.Links = append([]Link(nil), config.Links...)
 	var services []Service
 	// iterate over all ingresses and add them to the dashboard
 	for _, ingress := range ingresses.Items {
-		for _, rule := range ingress.Spec.Rules {
-			item := Item{}
-			service := Service{}
-			service.Name = ingress.ObjectMeta.Namespace
-			item.Name = ingress.ObjectMeta.Name
-			service.Logo = "https://raw.githubusercontent.com/kubernetes/community/master/icons/png/resources/labeled/ns-128.png"
-			if len(ingress.Spec.TLS) > 0 {
-				item.Url = "https://" + rule.Host
-			} else {
-				item.Url = "http://" + rule.Host
-			}
-			item.Logo = "https://raw.githubusercontent.com/kubernetes/community/master/icons/png/resources/labeled/ing-128.png"
-			item.Subtitle = rule.Host
-			for key, value := range ingress.ObjectMeta.Annotations {
-				if strings.HasPrefix(key, "item.homer.rajsingh.info/") {
-					fieldName := strings.TrimPrefix(key, "item.homer.rajsingh.info/")
-					reflect.ValueOf(&item).Elem().FieldByName(fieldName).SetString(value)
-				}
-				if strings.HasPrefix(key, "service.homer.rajsingh.info/") {
-					fieldName := strings.TrimPrefix(key, "service.homer.rajsingh.info/")
-					reflect.ValueOf(&service).Elem().FieldByName(fieldName).SetString(value)
+		// Visibility windows are applied later by ApplyVisibilityWindows.
+		evaluation := EvaluateIngress(ingress, options, time.Time{})
+		if !evaluation.Included() {
+			continue
+		}
+		hosts := evaluation.Hosts
+		if link, ok := linkFromIngress(ingress, hosts, options); ok {
+			addLink(config, link)
+			continue
+		}
+		for _, host := range hosts {
+			service, item := createIngressItem(ingress, host, options)
+			if nameTemplate != nil && len(hosts) > 1 {
+				if !hasItemAnnotation(ingress, "name") {
+					if item.Name, err = executeItemNameTemplate(nameTemplate, ingress, host); err != nil {
+						return err
+					}
 				}
 			}
-			service.Items = append(service.Items, item)
-			services = append(services, service)
+			services = append(services, groupedServices(ingress, service, item, options)...)
 		}
 	}
+	mergeDiscoveredServices(config, services, options)
+	return nil
+}
+
+// groupedServices returns a copy of service holding item for every service group named
+// in the comma separated item.homer.rajsingh.info/groups annotation, which overrides
+// the service name and so is only applied when the Name service field is allowed.
+// Without the annotation it returns service holding item.
+func groupedServices(ingress networkingv1.Ingress, service Service, item Item, options DiscoveryOptions) []Service {
+	var groups []string
+	annotation, ok := ingress.Annotations["item.homer.rajsingh.info/groups"]
+	if ok && !isFieldAllowed("Name", options.AllowedServiceFields, ingress, options) {
+		annotation = ""
+	}
+	for _, group := range strings.Split(annotation, ",") {
+		if group = strings.TrimSpace(group); group != "" && !slices.Contains(groups, group) {
+			groups = append(groups, group)
+		}
+	}
+	if len(groups) == 0 {
+		service.Items = append(service.Items, item)
+		return []Service{service}
+	}
+	services := make([]Service, 0, len(groups))
+	for _, group := range groups {
+		grouped := service
+		grouped.Name = group
+		grouped.Items = []Item{item}
+		services = append(services, grouped)
+	}
+	return services
+}
+
+// mergeDiscoveredServices adds the item of each discovered service to the config's
+// group of the same name, or adds the service as a new group. Frozen groups are left alone.
+func mergeDiscoveredServices(config *HomerConfig, services []Service, options DiscoveryOptions) {
 	for _, s1 := range services {
 		complete := false
 		for j, s2 := range config.Services {
 			if s1.Name == s2.Name {
-				config.Services[j].Items = append(s2.Items, s1.Items[0])
+				if s2.Frozen {
+					options.Logger.Info("Not adding discovered item to frozen service", "service", s2.Name,
+						"item", s1.Items[0].Name, "source", s1.Items[0].Source)
+				} else {
+					config.Services[j].Items = append(s2.Items, s1.Items[0])
+				}
 				complete = true
 				break
 			}
@@ -234,33 +666,451 @@ func UpdateHomerConfig(config *HomerConfig, ingresses networkingv1.IngressList)
 			config.Services = append(config.Services, s1)
 		}
 	}
+}
+
+// LimitServiceItems drops discovered items from every service group holding more than
+// max items and returns how many were dropped. Items defined in the Dashboard are never
+// dropped, but count towards the limit. A max of zero or less means no limit.
+func LimitServiceItems(config *HomerConfig, max int) int {
+	if max <= 0 {
+		return 0
+	}
+	dropped := 0
+	for i, service := range config.Services {
+		kept := 0
+		for _, item := range service.Items {
+			if item.Source == "" {
+				kept++
+			}
+		}
+		items := make([]Item, 0, len(service.Items))
+		for _, item := range service.Items {
+			switch {
+			case item.Source == "":
+				items = append(items, item)
+			case kept < max:
+				items = append(items, item)
+				kept++
+			default:
+				dropped++
+			}
+		}
+		config.Services[i].Items = items
+	}
+	return dropped
+}
+
+// SortStrategyRecent orders the discovered items of each service group by the time
+// their source was last updated, most recent first.
+const SortStrategyRecent = "recent"
+
+// SortServiceItems orders the items of each service group by strategy. Items defined in
+// the Dashboard keep their place at the top; an empty strategy keeps the discovery order.
+func SortServiceItems(config *HomerConfig, strategy string) {
+	if strategy != SortStrategyRecent {
+		return
+	}
+	for _, service := range config.Services {
+		slices.SortStableFunc(service.Items, func(a, b Item) int {
+			if (a.Source == "") != (b.Source == "") {
+				if a.Source == "" {
+					return -1
+				}
+				return 1
+			}
+			return b.LastUpdate.Compare(a.LastUpdate)
+		})
+	}
+}
+
+// LastUpdate returns when object was last written: the latest time in its managed
+// fields, or its creation time when no field manager recorded a later write.
+func LastUpdate(object metav1.Object) time.Time {
+	last := object.GetCreationTimestamp().Time
+	for _, entry := range object.GetManagedFields() {
+		if entry.Time != nil && entry.Time.After(last) {
+			last = entry.Time.Time
+		}
+	}
+	return last
+}
+
+// copyServices returns a copy of services that shares no slices with the original.
+func copyServices(services []Service) []Service {
+	if services == nil {
+		return nil
+	}
+	copied := make([]Service, len(services))
+	for i, service := range services {
+		copied[i] = service
+		copied[i].Items = append([]Item(nil), service.Items...)
+	}
+	return copied
+}
+
+// itemNameData is what an item name template is executed with.
+type itemNameData struct {
+	Name      string
+	Namespace string
+	Host      string
+}
+
+// parseItemNameTemplate parses an item name template. An empty text gives a nil template.
+func parseItemNameTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	nameTemplate, err := template.New("itemName").Funcs(template.FuncMap{
+		"trimSuffix": strings.TrimSuffix,
+		"trimPrefix": strings.TrimPrefix,
+		"replace":    strings.ReplaceAll,
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid item name template: %w", err)
+	}
+	return nameTemplate, nil
+}
+
+// executeItemNameTemplate names the item for one host of the ingress.
+func executeItemNameTemplate(nameTemplate *template.Template, ingress networkingv1.Ingress,
+	host string) (string, error) {
+	var name strings.Builder
+	data := itemNameData{Name: ingress.Name, Namespace: ingress.Namespace, Host: host}
+	if err := nameTemplate.Execute(&name, data); err != nil {
+		return "", fmt.Errorf("unable to name the item of ingress %s/%s: %w", ingress.Namespace, ingress.Name, err)
+	}
+	return name.String(), nil
+}
+
+// IngressHosts returns the host of every rule of the ingress. An ingress with only a
+// default backend has the host from its item.homer.rajsingh.info/host annotation, or
+// else the hostname or IP its load balancer reports, if any.
+func IngressHosts(ingress networkingv1.Ingress) []string {
+	if len(ingress.Spec.Rules) > 0 {
+		hosts := make([]string, 0, len(ingress.Spec.Rules))
+		for _, rule := range ingress.Spec.Rules {
+			hosts = append(hosts, rule.Host)
+		}
+		return hosts
+	}
+	if ingress.Spec.DefaultBackend == nil {
+		return nil
+	}
+	if host := ingress.ObjectMeta.Annotations["item.homer.rajsingh.info/host"]; host != "" {
+		return []string{host}
+	}
+	if host := loadBalancerHost(ingress); host != "" {
+		return []string{host}
+	}
 	return nil
 }
-func UpdateHomerConfigIngress(homerConfig *HomerConfig, ingress networkingv1.Ingress) {
+
+// loadBalancerHost returns the first hostname or IP the load balancer of the ingress
+// reports, or "" when it has none.
+func loadBalancerHost(ingress networkingv1.Ingress) string {
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.Hostname != "" {
+			return lb.Hostname
+		}
+		if lb.IP != "" {
+			return lb.IP
+		}
+	}
+	return ""
+}
+
+// discoveredHosts returns the hosts to create items for. With options.PreferStatusHostnames
+// an empty or wildcard rule host is replaced by the load balancer host, if there is one.
+func discoveredHosts(ingress networkingv1.Ingress, options DiscoveryOptions) []string {
+	hosts := IngressHosts(ingress)
+	status := loadBalancerHost(ingress)
+	if !options.PreferStatusHostnames || status == "" {
+		return hosts
+	}
+	resolved := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		if host == "" || strings.HasPrefix(host, "*.") {
+			host = status
+		}
+		if !slices.Contains(resolved, host) {
+			resolved = append(resolved, host)
+		}
+	}
+	return resolved
+}
+
+// createIngressItem builds the item for one host of the ingress along with the
+// service group it belongs to, applying the item and service annotations.
+func createIngressItem(ingress networkingv1.Ingress, host string, options DiscoveryOptions) (Service, Item) {
 	service := Service{}
 	item := Item{}
 	service.Name = ingress.ObjectMeta.Namespace
+	if options.ServiceGroup != "" {
+		service.Name = options.ServiceGroup
+	}
 	item.Name = ingress.ObjectMeta.Name
-	service.Logo = "https://raw.githubusercontent.com/kubernetes/community/master/icons/png/resources/labeled/ns-128.png"
-	if len(ingress.Spec.TLS) > 0 {
-		item.Url = "https://" + ingress.Spec.Rules[0].Host
-	} else {
-		item.Url = "http://" + ingress.Spec.Rules[0].Host
+	service.Logo = NamespaceIconURL
+	if options.ServiceLogo != "" {
+		service.Logo = options.ServiceLogo
+	}
+	item.Url = itemURL(ingressScheme(ingress, options), host, ingressPort(ingress, options))
+	item.Logo = IngressIconURL
+	if options.IngressLogo != "" {
+		item.Logo = options.IngressLogo
 	}
-	item.Logo = "https://raw.githubusercontent.com/kubernetes/community/master/icons/png/resources/labeled/ing-128.png"
-	item.Subtitle = ingress.Spec.Rules[0].Host
+	item.Subtitle = host
+	item.Source = "ingress/" + ingress.ObjectMeta.Namespace + "/" + ingress.ObjectMeta.Name
+	item.LastUpdate = LastUpdate(&ingress)
 	for key, value := range ingress.ObjectMeta.Annotations {
+		if key == "item.homer.rajsingh.info/smart-card" || key == "item.homer.rajsingh.info/scheme" ||
+			key == "item.homer.rajsingh.info/host" || key == "item.homer.rajsingh.info/port" ||
+			key == "item.homer.rajsingh.info/groups" {
+			continue
+		}
+		if key == "item.homer.rajsingh.info/after" {
+			item.After = value
+			continue
+		}
+		if key == "item.homer.rajsingh.info/before" {
+			item.Before = value
+			continue
+		}
+		if key == "item.homer.rajsingh.info/visible-from" || key == "item.homer.rajsingh.info/visible-until" {
+			applyVisibility(&item, key, value, ingress, options)
+			continue
+		}
+		if key == "service.homer.rajsingh.info/layout" {
+			if isFieldAllowed("Layout", options.AllowedServiceFields, ingress, options) {
+				applyServiceLayout(&service, value, ingress, options)
+			}
+			continue
+		}
 		if strings.HasPrefix(key, "item.homer.rajsingh.info/") {
 			fieldName := strings.TrimPrefix(key, "item.homer.rajsingh.info/")
-			reflect.ValueOf(&item).Elem().FieldByName(fieldName).SetString(value)
+			if strings.EqualFold(fieldName, "class") || strings.EqualFold(fieldName, "background") ||
+				strings.EqualFold(fieldName, "tagstyle") {
+				if isFieldAllowed(fieldName, options.AllowedItemFields, ingress, options) {
+					applyItemStyle(&item, strings.ToLower(fieldName), value, ingress, options)
+				}
+				continue
+			}
+			if isFieldAllowed(fieldName, options.AllowedItemFields, ingress, options) {
+				if !setAnnotatedField(&item, fieldName, value) {
+					logUnknownField(key, ingress, options)
+				}
+			}
 		}
 		if strings.HasPrefix(key, "service.homer.rajsingh.info/") {
 			fieldName := strings.TrimPrefix(key, "service.homer.rajsingh.info/")
-			reflect.ValueOf(&service).Elem().FieldByName(fieldName).SetString(value)
+			if isFieldAllowed(fieldName, options.AllowedServiceFields, ingress, options) {
+				if !setAnnotatedField(&service, fieldName, value) {
+					logUnknownField(key, ingress, options)
+				}
+			}
+		}
+	}
+	if len(options.KeywordLabels) > 0 {
+		words := []string{ingress.Namespace, options.ClusterName}
+		for _, label := range options.KeywordLabels {
+			words = append(words, ingress.Labels[label])
 		}
+		item.Keywords = appendKeywords(item.Keywords, words...)
 	}
+	if cardType, ok := ingress.ObjectMeta.Annotations["item.homer.rajsingh.info/smart-card"]; ok {
+		if isFieldAllowed("Type", options.AllowedItemFields, ingress, options) {
+			applySmartCard(&item, cardType)
+		}
+	}
+	return service, item
+}
+
+// setAnnotatedField sets the string field of target, a pointer to an Item or Service,
+// named by fieldName either as the Go field (Subtitle) or as the config.yml key
+// (subtitle, warning_value), ignoring case. It reports false for unknown fields and
+// for fields that cannot be set from an annotation.
+func setAnnotatedField(target interface{}, fieldName, value string) bool {
+	object := reflect.ValueOf(target).Elem()
+	for i := 0; i < object.NumField(); i++ {
+		field := object.Type().Field(i)
+		key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if field.Type.Kind() != reflect.String || key == "-" {
+			continue
+		}
+		if strings.EqualFold(field.Name, fieldName) || strings.EqualFold(key, fieldName) {
+			object.Field(i).SetString(value)
+			return true
+		}
+	}
+	return false
+}
+
+// hasItemAnnotation reports whether the ingress has an item.homer.rajsingh.info/<field>
+// annotation for field, ignoring case as setAnnotatedField does.
+func hasItemAnnotation(ingress networkingv1.Ingress, field string) bool {
+	for key := range ingress.Annotations {
+		if name, ok := strings.CutPrefix(key, "item.homer.rajsingh.info/"); ok && strings.EqualFold(name, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// logUnknownField reports an annotation naming no known field, which is usually a typo.
+func logUnknownField(annotation string, ingress networkingv1.Ingress, options DiscoveryOptions) {
+	options.Logger.Info("Ignoring annotation for an unknown field", "annotation", annotation,
+		"ingress", ingress.Namespace+"/"+ingress.Name)
+}
+
+// applyVisibility sets the visibility window bound of the item from its RFC 3339
+// visible-from or visible-until annotation. An unparsable time is logged and ignored.
+func applyVisibility(item *Item, key, value string, ingress networkingv1.Ingress, options DiscoveryOptions) {
+	bound, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		options.Logger.Info("Ignoring invalid visibility time", "annotation", key, "value", value,
+			"ingress", ingress.Namespace+"/"+ingress.Name)
+		return
+	}
+	if strings.HasSuffix(key, "/visible-from") {
+		item.VisibleFrom = bound
+	} else {
+		item.VisibleUntil = bound
+	}
+}
+
+// applyServiceLayout sets the layout of the service group from the
+// service.homer.rajsingh.info/layout annotation. An unknown layout is logged and, in
+// strict mode, dropped.
+func applyServiceLayout(service *Service, layout string, ingress networkingv1.Ingress, options DiscoveryOptions) {
+	if !isValidLayout(layout) {
+		options.Logger.Info("Ignoring unknown service layout", "layout", layout,
+			"ingress", ingress.Namespace+"/"+ingress.Name, "dropped", options.Strict)
+		if options.Strict {
+			return
+		}
+	}
+	service.Layout = layout
+}
+
+// applyItemStyle sets the class, background, or tagstyle of the item from its annotation. A value
+// that fails isValidItemStyle is logged and, in strict mode, dropped.
+func applyItemStyle(item *Item, field, value string, ingress networkingv1.Ingress, options DiscoveryOptions) {
+	if !isValidItemStyle(field, value) {
+		options.Logger.Info("Ignoring invalid item style", "field", field, "value", value,
+			"ingress", ingress.Namespace+"/"+ingress.Name, "dropped", options.Strict)
+		if options.Strict {
+			return
+		}
+	}
+	switch field {
+	case "class":
+		item.Class = value
+	case "tagstyle":
+		item.Tagstyle = value
+	default:
+		item.Background = value
+	}
+}
+
+// appendKeywords adds the non-empty words missing from the space separated keywords.
+func appendKeywords(keywords string, words ...string) string {
+	fields := strings.Fields(keywords)
+	for _, word := range words {
+		if word != "" && !slices.Contains(fields, word) {
+			fields = append(fields, word)
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// ingressPort returns the port from the item.homer.rajsingh.info/port annotation of the
+// ingress, or "" when it is not set or not a valid port.
+func ingressPort(ingress networkingv1.Ingress, options DiscoveryOptions) string {
+	port, ok := ingress.ObjectMeta.Annotations["item.homer.rajsingh.info/port"]
+	if !ok {
+		return ""
+	}
+	if number, err := strconv.Atoi(port); err != nil || number < 1 || number > 65535 {
+		options.Logger.Info("Ignoring invalid item port", "port", port, "ingress", ingress.Namespace+"/"+ingress.Name)
+		return ""
+	}
+	return port
+}
+
+// itemURL joins scheme and host into the URL of an item. A port replaces the one in
+// host, if any, and the default port of the scheme is left out, as a browser would.
+func itemURL(scheme, host, port string) string {
+	if hostname, hostPort, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+		if port == "" {
+			port = hostPort
+		}
+	}
+	if port == "" || (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+		if strings.Contains(host, ":") {
+			return scheme + "://[" + host + "]"
+		}
+		return scheme + "://" + host
+	}
+	return scheme + "://" + net.JoinHostPort(host, port)
+}
+
+// ingressScheme returns the URL scheme for the items of the ingress. The
+// item.homer.rajsingh.info/scheme annotation wins over options.DefaultScheme,
+// which wins over guessing https from the TLS block of the ingress.
+func ingressScheme(ingress networkingv1.Ingress, options DiscoveryOptions) string {
+	switch scheme := ingress.ObjectMeta.Annotations["item.homer.rajsingh.info/scheme"]; scheme {
+	case "http", "https":
+		return scheme
+	case "":
+	default:
+		options.Logger.Info("Ignoring unknown scheme annotation", "scheme", scheme,
+			"ingress", ingress.Namespace+"/"+ingress.Name)
+	}
+	if options.DefaultScheme != "" {
+		return options.DefaultScheme
+	}
+	if len(ingress.Spec.TLS) > 0 {
+		return "https"
+	}
+	return "http"
+}
+
+// isFieldAllowed checks an annotation-derived field against the allowlist. Fields
+// outside a non-empty allowlist are logged, and rejected when options.Strict is set.
+func isFieldAllowed(fieldName string, allowed []string, ingress networkingv1.Ingress, options DiscoveryOptions) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, field := range allowed {
+		if strings.EqualFold(field, fieldName) {
+			return true
+		}
+	}
+	options.Logger.Info("Annotation field is not in the allowed fields", "field", fieldName,
+		"ingress", ingress.Namespace+"/"+ingress.Name, "dropped", options.Strict)
+	return !options.Strict
+}
+
+func UpdateHomerConfigIngress(homerConfig *HomerConfig, ingress networkingv1.Ingress, options DiscoveryOptions) {
+	if link, ok := linkFromIngress(ingress, IngressHosts(ingress), options); ok {
+		addLink(homerConfig, link)
+		return
+	}
+	hosts := discoveredHosts(ingress, options)
+	if len(hosts) == 0 {
+		return
+	}
+	service, item := createIngressItem(ingress, hosts[0], options)
+	for _, grouped := range groupedServices(ingress, service, item, options) {
+		updateServiceItem(homerConfig, grouped.Name, item)
+	}
+}
+
+// updateServiceItem replaces the item of the same name in the named service group, or
+// appends item to it. A config without that group is left alone.
+func updateServiceItem(homerConfig *HomerConfig, serviceName string, item Item) {
 	for sx, s := range homerConfig.Services {
-		if s.Name == service.Name {
+		if s.Name == serviceName {
 			for ix, i := range s.Items {
 				if i.Name == item.Name {
 					homerConfig.Services[sx].Items[ix] = item
@@ -272,16 +1122,45 @@ func UpdateHomerConfigIngress(homerConfig *HomerConfig, ingress networkingv1.Ing
 	}
 }
 
-func UpdateConfigMapIngress(cm *corev1.ConfigMap, ingress networkingv1.Ingress) {
+// linkFromIngress builds a top navigation Link from the link.homer.rajsingh.info/*
+// annotations of the ingress. The url defaults to the first of hosts.
+func linkFromIngress(ingress networkingv1.Ingress, hosts []string, options DiscoveryOptions) (Link, bool) {
+	link := Link{
+		Name:   ingress.ObjectMeta.Annotations["link.homer.rajsingh.info/name"],
+		Url:    ingress.ObjectMeta.Annotations["link.homer.rajsingh.info/url"],
+		Icon:   ingress.ObjectMeta.Annotations["link.homer.rajsingh.info/icon"],
+		Target: ingress.ObjectMeta.Annotations["link.homer.rajsingh.info/target"],
+	}
+	if link.Name == "" {
+		return link, false
+	}
+	if link.Url == "" && len(hosts) > 0 {
+		link.Url = itemURL(ingressScheme(ingress, options), hosts[0], ingressPort(ingress, options))
+	}
+	return link, link.Url != ""
+}
+
+// addLink appends the link to the config unless a link with the same name and url exists.
+func addLink(config *HomerConfig, link Link) {
+	for i, l := range config.Links {
+		if l.Name == link.Name && l.Url == link.Url {
+			config.Links[i] = link
+			return
+		}
+	}
+	config.Links = append(config.Links, link)
+}
+
+func UpdateConfigMapIngress(cm *corev1.ConfigMap, ingress networkingv1.Ingress, options DiscoveryOptions) {
 	homerConfig := HomerConfig{}
-	err := yaml.Unmarshal([]byte(cm.Data["config.yml"]), &homerConfig)
+	err := yaml.Unmarshal([]byte(cm.Data[DefaultConfigKey]), &homerConfig)
 	if err != nil {
 		return
 	}
-	UpdateHomerConfigIngress(&homerConfig, ingress)
-	objYAML, err := yaml.Marshal(homerConfig)
+	UpdateHomerConfigIngress(&homerConfig, ingress, options)
+	objYAML, err := marshalHomerConfigToYAML(homerConfig, RenderOptions{})
 	if err != nil {
 		return
 	}
-	cm.Data["config.yml"] = string(objYAML)
-}
\ No newline at end of file
+	cm.Data[DefaultConfigKey] = string(objYAML)
+}
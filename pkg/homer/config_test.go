@@ -0,0 +1,821 @@
+package homer
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMarshalHomerConfigUsesHomerKeys(t *testing.T) {
+	config := HomerConfig{
+		Title:    "Dashboard",
+		Defaults: DefaultConfig{ColorTheme: "auto"},
+		Services: []Service{{
+			Name:  "media",
+			Items: []Item{{Name: "jellyfin", Legacyapi: "true", Librarytype: "movies", Warningvalue: "50"}},
+		}},
+	}
+	out, err := marshalHomerConfigToYAML(config, RenderOptions{})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	keys := []string{"colorTheme: auto", "legacyApi: \"true\"", "libraryType: movies", "warning_value: \"50\""}
+	for _, key := range keys {
+		if !strings.Contains(string(out), key) {
+			t.Errorf("expected %q in generated config:\n%s", key, out)
+		}
+	}
+}
+
+func TestMarshalHomerConfigAppliesKeyOverrides(t *testing.T) {
+	config := HomerConfig{
+		Services: []Service{{Name: "media", Items: []Item{{Name: "jellyfin", Apikey: "secret"}}}},
+	}
+	out, err := marshalHomerConfigToYAML(config, RenderOptions{KeyOverrides: map[string]string{"apikey": "apiKey"}})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(out), "apiKey: secret") || strings.Contains(string(out), "apikey:") {
+		t.Errorf("expected apikey to be renamed to apiKey:\n%s", out)
+	}
+}
+
+func TestLimitServiceItemsKeepsDashboardItems(t *testing.T) {
+	config := HomerConfig{
+		Services: []Service{{
+			Name: "apps",
+			Items: []Item{
+				{Name: "curated"},
+				{Name: "a", Source: "ingress/default/a"},
+				{Name: "b", Source: "ingress/default/b"},
+				{Name: "c", Source: "ingress/default/c"},
+			},
+		}},
+	}
+	if dropped := LimitServiceItems(&config, 2); dropped != 2 {
+		t.Fatalf("expected 2 dropped items, got %d", dropped)
+	}
+	items := config.Services[0].Items
+	if len(items) != 2 || items[0].Name != "curated" || items[1].Name != "a" {
+		t.Errorf("unexpected items after limit: %+v", items)
+	}
+}
+
+func TestIngressScheme(t *testing.T) {
+	withTLS := networkingv1.IngressSpec{TLS: []networkingv1.IngressTLS{{Hosts: []string{"app.example.com"}}}}
+	tests := []struct {
+		name       string
+		annotation string
+		spec       networkingv1.IngressSpec
+		options    DiscoveryOptions
+		want       string
+	}{
+		{name: "no tls", want: "http"},
+		{name: "tls", spec: withTLS, want: "https"},
+		{name: "default scheme", options: DiscoveryOptions{DefaultScheme: "https"}, want: "https"},
+		{name: "annotation over tls", annotation: "http", spec: withTLS, want: "http"},
+		{
+			name:       "annotation over default",
+			annotation: "http",
+			options:    DiscoveryOptions{DefaultScheme: "https"},
+			want:       "http",
+		},
+		{name: "unknown annotation", annotation: "ftp", spec: withTLS, want: "https"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ingress := networkingv1.Ingress{Spec: tt.spec}
+			if tt.annotation != "" {
+				ingress.Annotations = map[string]string{"item.homer.rajsingh.info/scheme": tt.annotation}
+			}
+			if got := ingressScheme(ingress, tt.options); got != tt.want {
+				t.Errorf("ingressScheme() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateHomerConfigSkipsFrozenServices(t *testing.T) {
+	config := HomerConfig{
+		Services: []Service{{Name: "default", Frozen: true, Items: []Item{{Name: "curated"}}}},
+	}
+	ingresses := networkingv1.IngressList{Items: []networkingv1.Ingress{{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: "app.example.com"}}},
+	}}}
+	if err := UpdateHomerConfig(&config, ingresses, DiscoveryOptions{}); err != nil {
+		t.Fatalf("UpdateHomerConfig: %v", err)
+	}
+	if len(config.Services) != 1 || len(config.Services[0].Items) != 1 {
+		t.Errorf("expected the frozen service to keep only its own item, got %+v", config.Services)
+	}
+}
+
+func TestMarshalHomerConfigWithExternalConfig(t *testing.T) {
+	config := HomerConfig{
+		Title:          "Dashboard",
+		ExternalConfig: "https://example.com/config.yml",
+		Services: []Service{{
+			Name: "apps",
+			Items: []Item{
+				{Name: "curated"},
+				{Name: "grafana", Source: "ingress/monitoring/grafana"},
+			},
+		}},
+	}
+	out, err := marshalHomerConfigToYAML(config, RenderOptions{})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := "externalConfig: https://example.com/config.yml\nservices:\n- name: apps\n"
+	if !strings.HasPrefix(string(out), want) || strings.Contains(string(out), "curated") ||
+		strings.Contains(string(out), "title: Dashboard") || !strings.Contains(string(out), "grafana") {
+		t.Errorf("expected only externalConfig and discovered items:\n%s", out)
+	}
+}
+
+func TestMarshalHomerConfigMinimal(t *testing.T) {
+	config := HomerConfig{
+		Title:    "Dashboard",
+		Defaults: DefaultConfig{Layout: "columns", ColorTheme: "dark"},
+		Services: []Service{{Name: "apps", Items: []Item{{Name: "grafana", Url: "https://grafana.example.com"}}}},
+	}
+	out, err := marshalHomerConfigToYAML(config, RenderOptions{Minimal: true})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `title: Dashboard
+services:
+- name: apps
+  items:
+  - name: grafana
+    url: https://grafana.example.com
+defaults:
+  colorTheme: dark
+`
+	if string(out) != want {
+		t.Errorf("unexpected minimal config:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestMarshalHomerConfigMinimalKeepsServiceLayout(t *testing.T) {
+	config := HomerConfig{
+		Title:    "Dashboard",
+		Defaults: DefaultConfig{Layout: "list"},
+		Services: []Service{{Name: "apps", Layout: "columns"}},
+	}
+	out, err := marshalHomerConfigToYAML(config, RenderOptions{Minimal: true})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `title: Dashboard
+services:
+- name: apps
+  layout: columns
+defaults:
+  layout: list
+`
+	if string(out) != want {
+		t.Errorf("unexpected minimal config:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestCreateIngressItemDefaultLogos(t *testing.T) {
+	ingress := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+	service, item := createIngressItem(ingress, "app.example.com", DiscoveryOptions{})
+	if item.Logo != IngressIconURL || service.Logo != NamespaceIconURL {
+		t.Errorf("expected the Kubernetes icons by default, got %q and %q", item.Logo, service.Logo)
+	}
+	options := DiscoveryOptions{IngressLogo: "/assets/icons/ingress.png", ServiceLogo: "/assets/icons/ns.png"}
+	service, item = createIngressItem(ingress, "app.example.com", options)
+	if item.Logo != options.IngressLogo || service.Logo != options.ServiceLogo {
+		t.Errorf("expected the configured logos, got %q and %q", item.Logo, service.Logo)
+	}
+}
+
+func TestUpdateHomerConfigConcurrentConfigs(t *testing.T) {
+	base := HomerConfig{Services: []Service{{Name: "default", Items: []Item{{Name: "curated"}}}}}
+	ingresses := networkingv1.IngressList{Items: []networkingv1.Ingress{{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: "app.example.com"}}},
+	}}}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			config := base
+			if err := UpdateHomerConfig(&config, ingresses, DiscoveryOptions{}); err != nil {
+				t.Errorf("UpdateHomerConfig: %v", err)
+			}
+			if len(config.Services[0].Items) != 2 {
+				t.Errorf("expected 2 items, got %+v", config.Services[0].Items)
+			}
+		}()
+	}
+	wg.Wait()
+	if len(base.Services[0].Items) != 1 {
+		t.Errorf("expected the shared config to be left alone, got %+v", base.Services[0].Items)
+	}
+}
+
+func TestUpdateHomerConfigDefaultBackendIngress(t *testing.T) {
+	backend := &networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "app"}}
+	withAnnotation := networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "catch-all",
+			Namespace:   "default",
+			Annotations: map[string]string{"item.homer.rajsingh.info/host": "apps.example.com"},
+		},
+		Spec: networkingv1.IngressSpec{DefaultBackend: backend},
+	}
+	withStatus := networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "lb", Namespace: "default"},
+		Spec:       networkingv1.IngressSpec{DefaultBackend: backend},
+		Status: networkingv1.IngressStatus{LoadBalancer: networkingv1.IngressLoadBalancerStatus{
+			Ingress: []networkingv1.IngressLoadBalancerIngress{{IP: "192.0.2.10"}},
+		}},
+	}
+	empty := networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "default"},
+		Spec:       networkingv1.IngressSpec{DefaultBackend: backend},
+	}
+	config := HomerConfig{}
+	ingresses := networkingv1.IngressList{Items: []networkingv1.Ingress{withAnnotation, withStatus, empty}}
+	if err := UpdateHomerConfig(&config, ingresses, DiscoveryOptions{}); err != nil {
+		t.Fatalf("UpdateHomerConfig: %v", err)
+	}
+	items := config.Services[0].Items
+	if len(items) != 2 || items[0].Url != "http://apps.example.com" || items[1].Url != "http://192.0.2.10" {
+		t.Errorf("unexpected items: %+v", items)
+	}
+}
+
+func TestUpdateHomerConfigItemNameTemplate(t *testing.T) {
+	ingress := networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "grafana", Namespace: "monitoring"},
+		Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{
+			{Host: "grafana.eu.example.com"},
+			{Host: "grafana.us.example.com"},
+			{Host: "grafana.internal.example.org"},
+		}},
+	}
+	options := DiscoveryOptions{
+		DomainFilters:    []string{"example.com"},
+		ItemNameTemplate: `{{ .Name }} ({{ trimPrefix (trimSuffix .Host ".example.com") "grafana." }})`,
+	}
+	config := HomerConfig{}
+	ingresses := networkingv1.IngressList{Items: []networkingv1.Ingress{ingress}}
+	if err := UpdateHomerConfig(&config, ingresses, options); err != nil {
+		t.Fatalf("UpdateHomerConfig: %v", err)
+	}
+	items := config.Services[0].Items
+	if len(items) != 2 || items[0].Name != "grafana (eu)" || items[1].Name != "grafana (us)" {
+		t.Errorf("unexpected items: %+v", items)
+	}
+
+	for _, key := range []string{"item.homer.rajsingh.info/Name", "item.homer.rajsingh.info/name"} {
+		ingress.Annotations = map[string]string{key: "Grafana"}
+		config = HomerConfig{}
+		ingresses = networkingv1.IngressList{Items: []networkingv1.Ingress{ingress}}
+		if err := UpdateHomerConfig(&config, ingresses, options); err != nil {
+			t.Fatalf("UpdateHomerConfig: %v", err)
+		}
+		for _, item := range config.Services[0].Items {
+			if item.Name != "Grafana" {
+				t.Errorf("expected the %s annotation to win over the template, got %q", key, item.Name)
+			}
+		}
+	}
+
+	options.ItemNameTemplate = "{{ .Name"
+	if err := UpdateHomerConfig(&HomerConfig{}, networkingv1.IngressList{}, options); err == nil {
+		t.Error("expected an invalid template to fail")
+	}
+}
+
+func TestCreateIngressItemServiceLayout(t *testing.T) {
+	ingress := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Name:        "app",
+		Namespace:   "default",
+		Annotations: map[string]string{"service.homer.rajsingh.info/layout": "list"},
+	}}
+	service, _ := createIngressItem(ingress, "app.example.com", DiscoveryOptions{})
+	if service.Layout != "list" {
+		t.Errorf("expected layout list, got %q", service.Layout)
+	}
+	ingress.Annotations["service.homer.rajsingh.info/layout"] = "grid"
+	service, _ = createIngressItem(ingress, "app.example.com", DiscoveryOptions{})
+	if service.Layout != "grid" {
+		t.Errorf("expected the unknown layout to be kept outside strict mode, got %q", service.Layout)
+	}
+	service, _ = createIngressItem(ingress, "app.example.com", DiscoveryOptions{Strict: true})
+	if service.Layout != "" {
+		t.Errorf("expected the unknown layout to be dropped in strict mode, got %q", service.Layout)
+	}
+	config := HomerConfig{Services: []Service{{Name: "default", Layout: "list"}}}
+	out, err := marshalHomerConfigToYAML(config, RenderOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "layout: list") {
+		t.Errorf("expected the layout in the rendered config:\n%s", out)
+	}
+}
+
+func TestUpdateHomerConfigExcludePatterns(t *testing.T) {
+	ingress := func(name string, hosts ...string) networkingv1.Ingress {
+		ingress := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		for _, host := range hosts {
+			ingress.Spec.Rules = append(ingress.Spec.Rules, networkingv1.IngressRule{Host: host})
+		}
+		return ingress
+	}
+	ingresses := networkingv1.IngressList{Items: []networkingv1.Ingress{
+		ingress("web", "web.example.com", "web.canary.example.com"),
+		ingress("cm-acme-http-solver-x7k2p", "web.example.com"),
+	}}
+	options := DiscoveryOptions{
+		ExcludeHostPatterns: []string{"*.canary.example.com"},
+		ExcludeNamePatterns: []string{"cm-acme-http-solver-*"},
+	}
+	config := HomerConfig{}
+	if err := UpdateHomerConfig(&config, ingresses, options); err != nil {
+		t.Fatalf("UpdateHomerConfig: %v", err)
+	}
+	items := config.Services[0].Items
+	if len(items) != 1 || items[0].Name != "web" || items[0].Url != "http://web.example.com" {
+		t.Errorf("unexpected items: %+v", items)
+	}
+}
+
+func TestCreateConfigMapConfigKey(t *testing.T) {
+	config := HomerConfig{Title: "Dashboard"}
+	configMap := CreateConfigMap(config, "dashboard", "default", "", RenderOptions{})
+	if _, ok := configMap.Data["config.yml"]; !ok || len(configMap.Data) != 1 {
+		t.Errorf("expected only config.yml by default, got keys of %v", configMap.Data)
+	}
+	configMap = CreateConfigMap(config, "dashboard", "default", "", RenderOptions{ConfigKey: "config.yaml"})
+	if !strings.Contains(configMap.Data["config.yaml"], "title: Dashboard") || len(configMap.Data) != 1 {
+		t.Errorf("expected only config.yaml, got %v", configMap.Data)
+	}
+}
+
+func TestCreateIngressItemStyle(t *testing.T) {
+	ingress := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Name:      "app",
+		Namespace: "default",
+		Annotations: map[string]string{
+			"item.homer.rajsingh.info/class":      "highlight",
+			"item.homer.rajsingh.info/background": "red; display: none",
+			"item.homer.rajsingh.info/tagstyle":   "is-sucess",
+		},
+	}}
+	_, item := createIngressItem(ingress, "app.example.com", DiscoveryOptions{Strict: true})
+	if item.Class != "highlight" || item.Background != "" || item.Tagstyle != "" {
+		t.Errorf("expected the class only, got %q, %q, and %q", item.Class, item.Background, item.Tagstyle)
+	}
+	_, item = createIngressItem(ingress, "app.example.com", DiscoveryOptions{})
+	if item.Tagstyle != "is-sucess" {
+		t.Errorf("expected the tagstyle to be kept outside strict mode, got %q", item.Tagstyle)
+	}
+	config := HomerConfig{Services: []Service{{Name: "default", Items: []Item{item}}}}
+	out, err := marshalHomerConfigToYAML(config, RenderOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "class: highlight") {
+		t.Errorf("expected the class in the rendered config:\n%s", out)
+	}
+}
+
+func TestCreateConfigMapChecksum(t *testing.T) {
+	first := CreateConfigMap(HomerConfig{Title: "Dashboard"}, "dashboard", "default", "", RenderOptions{})
+	again := CreateConfigMap(HomerConfig{Title: "Dashboard"}, "dashboard", "default", "", RenderOptions{})
+	changed := CreateConfigMap(HomerConfig{Title: "Apps"}, "dashboard", "default", "", RenderOptions{})
+	checksum := first.Annotations[ConfigChecksumAnnotation]
+	if checksum != ConfigChecksum([]byte(first.Data["config.yml"])) {
+		t.Errorf("expected the checksum of config.yml, got %q", checksum)
+	}
+	if again.Annotations[ConfigChecksumAnnotation] != checksum {
+		t.Error("expected the same config to keep its checksum")
+	}
+	if changed.Annotations[ConfigChecksumAnnotation] == checksum {
+		t.Error("expected a changed config to change the checksum")
+	}
+}
+
+func TestItemURL(t *testing.T) {
+	tests := []struct {
+		scheme, host, port string
+		want               string
+	}{
+		{scheme: "http", host: "app.example.com", want: "http://app.example.com"},
+		{scheme: "http", host: "app.internal:80", want: "http://app.internal"},
+		{scheme: "https", host: "app.internal:443", want: "https://app.internal"},
+		{scheme: "https", host: "app.internal:80", want: "https://app.internal:80"},
+		{scheme: "http", host: "app.internal:80", port: "8080", want: "http://app.internal:8080"},
+		{scheme: "https", host: "app.example.com", port: "8443", want: "https://app.example.com:8443"},
+		{scheme: "http", host: "2001:db8::1", want: "http://[2001:db8::1]"},
+		{scheme: "http", host: "2001:db8::1", port: "8080", want: "http://[2001:db8::1]:8080"},
+	}
+	for _, tt := range tests {
+		if got := itemURL(tt.scheme, tt.host, tt.port); got != tt.want {
+			t.Errorf("itemURL(%q, %q, %q) = %q, want %q", tt.scheme, tt.host, tt.port, got, tt.want)
+		}
+	}
+}
+
+func TestCreateIngressItemPort(t *testing.T) {
+	ingress := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Name:        "app",
+		Namespace:   "default",
+		Annotations: map[string]string{"item.homer.rajsingh.info/port": "8080"},
+	}}
+	_, item := createIngressItem(ingress, "app.example.com", DiscoveryOptions{})
+	if item.Url != "http://app.example.com:8080" {
+		t.Errorf("expected the annotated port, got %q", item.Url)
+	}
+	ingress.Annotations["item.homer.rajsingh.info/port"] = "http"
+	_, item = createIngressItem(ingress, "app.example.com", DiscoveryOptions{})
+	if item.Url != "http://app.example.com" {
+		t.Errorf("expected an invalid port to be ignored, got %q", item.Url)
+	}
+}
+
+func TestMarshalHomerConfigConnectivityCheck(t *testing.T) {
+	disabled := false
+	for _, tt := range []struct {
+		check *bool
+		want  string
+	}{
+		{check: nil, want: ""},
+		{check: &disabled, want: "connectivityCheck: false"},
+	} {
+		for _, minimal := range []bool{false, true} {
+			out, err := marshalHomerConfigToYAML(HomerConfig{Title: "Dashboard", ConnectivityCheck: tt.check},
+				RenderOptions{Minimal: minimal})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Contains(string(out), "connectivityCheck"); got != (tt.want != "") ||
+				!strings.Contains(string(out), tt.want) {
+				t.Errorf("expected %q in the config (minimal %v), got:\n%s", tt.want, minimal, out)
+			}
+		}
+	}
+}
+
+func TestCreateIngressItemKeywordsFromLabels(t *testing.T) {
+	ingress := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Name:        "grafana",
+		Namespace:   "monitoring",
+		Labels:      map[string]string{"team": "platform", "tier": "observability"},
+		Annotations: map[string]string{"item.homer.rajsingh.info/Keywords": "metrics platform"},
+	}}
+	options := DiscoveryOptions{KeywordLabels: []string{"team", "tier", "missing"}, ClusterName: "prod-eu"}
+	_, item := createIngressItem(ingress, "grafana.example.com", options)
+	if want := "metrics platform monitoring prod-eu observability"; item.Keywords != want {
+		t.Errorf("expected keywords %q, got %q", want, item.Keywords)
+	}
+	_, item = createIngressItem(ingress, "grafana.example.com", DiscoveryOptions{})
+	if item.Keywords != "metrics platform" {
+		t.Errorf("expected only the annotated keywords without KeywordLabels, got %q", item.Keywords)
+	}
+}
+
+func TestCreateIngressItemAnnotatedFields(t *testing.T) {
+	ingress := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Name:      "app",
+		Namespace: "default",
+		Annotations: map[string]string{
+			"item.homer.rajsingh.info/subtitle":      "lower case",
+			"item.homer.rajsingh.info/warning_value": "50",
+			"item.homer.rajsingh.info/subtitel":      "typo",
+			"item.homer.rajsingh.info/Source":        "forged",
+			"service.homer.rajsingh.info/Icon":       "fas fa-code",
+			"service.homer.rajsingh.info/Frozen":     "true",
+			"service.homer.rajsingh.info/items":      "none",
+		},
+	}}
+	service, item := createIngressItem(ingress, "app.example.com", DiscoveryOptions{})
+	if item.Subtitle != "lower case" || item.Warningvalue != "50" {
+		t.Errorf("expected fields by config key, got subtitle %q and warning_value %q", item.Subtitle, item.Warningvalue)
+	}
+	if item.Source != "ingress/default/app" {
+		t.Errorf("expected the source not to be settable, got %q", item.Source)
+	}
+	if service.Icon != "fas fa-code" || service.Frozen || len(service.Items) != 0 {
+		t.Errorf("unexpected service: %+v", service)
+	}
+}
+
+func TestSortServiceItemsRecent(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	config := HomerConfig{Services: []Service{{Name: "apps", Items: []Item{
+		{Name: "old", Source: "ingress/apps/old", LastUpdate: now.Add(-time.Hour)},
+		{Name: "pinned"},
+		{Name: "new", Source: "ingress/apps/new", LastUpdate: now},
+	}}}}
+	SortServiceItems(&config, "")
+	if config.Services[0].Items[0].Name != "old" {
+		t.Fatalf("expected no strategy to keep the discovery order, got %v", config.Services[0].Items)
+	}
+	SortServiceItems(&config, SortStrategyRecent)
+	var names []string
+	for _, item := range config.Services[0].Items {
+		names = append(names, item.Name)
+	}
+	if !slices.Equal(names, []string{"pinned", "new", "old"}) {
+		t.Errorf("expected defined items first and then the most recent, got %v", names)
+	}
+}
+
+func TestLastUpdate(t *testing.T) {
+	created := metav1.NewTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	updated := metav1.NewTime(created.Add(time.Hour))
+	ingress := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created}}
+	if got := LastUpdate(&ingress); !got.Equal(created.Time) {
+		t.Errorf("expected the creation time, got %v", got)
+	}
+	ingress.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl", Time: &updated}, {Manager: "helm"}}
+	if got := LastUpdate(&ingress); !got.Equal(updated.Time) {
+		t.Errorf("expected the time of the last write, got %v", got)
+	}
+}
+
+func TestCreateIngressItemPassthroughFields(t *testing.T) {
+	ingress := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Name:      "grafana",
+		Namespace: "monitoring",
+		Annotations: map[string]string{
+			"item.homer.rajsingh.info/target":      "_self",
+			"item.homer.rajsingh.info/icon":        "fas fa-chart-line",
+			"item.homer.rajsingh.info/legacyapi":   "true",
+			"item.homer.rajsingh.info/LibraryType": "movies",
+		},
+	}}
+	_, item := createIngressItem(ingress, "grafana.example.com", DiscoveryOptions{})
+	config := HomerConfig{Services: []Service{{Name: "monitoring", Items: []Item{item}}}}
+	out, err := marshalHomerConfigToYAML(config, RenderOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"target: _self", "icon: fas fa-chart-line", `legacyApi: "true"`, "libraryType: movies"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("expected %q in the rendered config:\n%s", want, out)
+		}
+	}
+}
+
+func TestDiscoveredHostsPreferStatusHostnames(t *testing.T) {
+	ingress := networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{
+			{Host: "*.example.com"}, {Host: ""}, {Host: "app.example.com"},
+		}},
+		Status: networkingv1.IngressStatus{LoadBalancer: networkingv1.IngressLoadBalancerStatus{
+			Ingress: []networkingv1.IngressLoadBalancerIngress{{Hostname: "lb.example.net"}},
+		}},
+	}
+	got := discoveredHosts(ingress, DiscoveryOptions{})
+	if !slices.Equal(got, []string{"*.example.com", "", "app.example.com"}) {
+		t.Errorf("expected the rule hosts by default, got %v", got)
+	}
+	got = discoveredHosts(ingress, DiscoveryOptions{PreferStatusHostnames: true})
+	if !slices.Equal(got, []string{"lb.example.net", "app.example.com"}) {
+		t.Errorf("expected the load balancer host for the empty and wildcard rules, got %v", got)
+	}
+	ingress.Status = networkingv1.IngressStatus{}
+	if got := discoveredHosts(ingress, DiscoveryOptions{PreferStatusHostnames: true}); len(got) != 3 {
+		t.Errorf("expected the rule hosts without a load balancer host, got %v", got)
+	}
+}
+
+func TestUpdateHomerConfigGroups(t *testing.T) {
+	ingress := networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "grafana",
+			Namespace: "monitoring",
+			Annotations: map[string]string{
+				"item.homer.rajsingh.info/groups":  "Monitoring, Team A,Monitoring",
+				"service.homer.rajsingh.info/name": "Ignored",
+			},
+		},
+		Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: "grafana.example.com"}}},
+	}
+	config := HomerConfig{Services: []Service{{Name: "Monitoring", Items: []Item{{Name: "prometheus"}}}}}
+	list := networkingv1.IngressList{Items: []networkingv1.Ingress{ingress}}
+	if err := UpdateHomerConfig(&config, list, DiscoveryOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Services) != 2 {
+		t.Fatalf("expected the Monitoring and Team A groups, got %+v", config.Services)
+	}
+	if got := itemNames(config.Services[0].Items); !slices.Equal(got, []string{"prometheus", "grafana"}) {
+		t.Errorf("expected grafana added to Monitoring, got %v", got)
+	}
+	if config.Services[1].Name != "Team A" || len(config.Services[1].Items) != 1 {
+		t.Errorf("expected grafana in Team A, got %+v", config.Services[1])
+	}
+}
+
+func TestUpdateHomerConfigGroupsAllowedServiceFields(t *testing.T) {
+	ingress := networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "grafana",
+			Namespace:   "monitoring",
+			Annotations: map[string]string{"item.homer.rajsingh.info/groups": "Admin"},
+		},
+		Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: "grafana.example.com"}}},
+	}
+	list := networkingv1.IngressList{Items: []networkingv1.Ingress{ingress}}
+	for _, tc := range []struct {
+		options DiscoveryOptions
+		want    string
+	}{
+		{DiscoveryOptions{AllowedServiceFields: []string{"Name"}, Strict: true}, "Admin"},
+		{DiscoveryOptions{AllowedServiceFields: []string{"Icon"}}, "Admin"},
+		{DiscoveryOptions{AllowedServiceFields: []string{"Icon"}, Strict: true}, "monitoring"},
+	} {
+		config := HomerConfig{}
+		if err := UpdateHomerConfig(&config, list, tc.options); err != nil {
+			t.Fatal(err)
+		}
+		if len(config.Services) != 1 || config.Services[0].Name != tc.want {
+			t.Errorf("with %+v expected the %s group, got %+v", tc.options, tc.want, config.Services)
+		}
+	}
+}
+
+func TestCreateIngressItemServiceGroup(t *testing.T) {
+	ingress := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"}}
+	service, _ := createIngressItem(ingress, "app.example.com", DiscoveryOptions{ServiceGroup: "Services"})
+	if service.Name != "Services" {
+		t.Errorf("expected the configured service group, got %q", service.Name)
+	}
+	ingress.Annotations = map[string]string{"service.homer.rajsingh.info/name": "Tools"}
+	service, _ = createIngressItem(ingress, "app.example.com", DiscoveryOptions{ServiceGroup: "Services"})
+	if service.Name != "Tools" {
+		t.Errorf("expected the annotation to win, got %q", service.Name)
+	}
+}
+
+func TestStylesheetPaths(t *testing.T) {
+	paths, err := StylesheetPaths(map[string]string{"theme.css": "", "custom.css": ""}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(paths, []string{"assets/custom/custom.css", "assets/custom/theme.css"}) {
+		t.Errorf("expected the sorted asset paths, got %v", paths)
+	}
+	for _, name := range []string{"custom.txt", "../custom.css", ".css", "dir/custom.css"} {
+		if _, err := StylesheetPaths(map[string]string{name: ""}, nil); err == nil {
+			t.Errorf("expected %q to be rejected", name)
+		}
+	}
+	if _, err := StylesheetPaths(map[string]string{"custom.css": ""}, map[string][]byte{"custom.css": nil}); err == nil {
+		t.Error("expected a stylesheet named like an asset file to be rejected")
+	}
+}
+
+func TestUpdateHomerConfigLinks(t *testing.T) {
+	linked := func(name string, annotations map[string]string) networkingv1.Ingress {
+		return networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "docs", Annotations: annotations},
+			Spec: networkingv1.IngressSpec{
+				Rules: []networkingv1.IngressRule{{Host: name + ".example.com"}},
+				TLS:   []networkingv1.IngressTLS{{Hosts: []string{name + ".example.com"}}},
+			},
+		}
+	}
+	list := networkingv1.IngressList{Items: []networkingv1.Ingress{
+		linked("wiki", map[string]string{"link.homer.rajsingh.info/name": "Wiki"}),
+		linked("wiki-mirror", map[string]string{
+			"link.homer.rajsingh.info/name": "Wiki",
+			"link.homer.rajsingh.info/url":  "https://wiki.example.com",
+		}),
+		linked("status", map[string]string{
+			"link.homer.rajsingh.info/name":   "Status",
+			"link.homer.rajsingh.info/url":    "https://status.example.org",
+			"link.homer.rajsingh.info/icon":   "fas fa-heartbeat",
+			"link.homer.rajsingh.info/target": "_blank",
+		}),
+	}}
+	config := HomerConfig{}
+	if err := UpdateHomerConfig(&config, list, DiscoveryOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	want := []Link{
+		{Name: "Wiki", Url: "https://wiki.example.com"},
+		{Name: "Status", Url: "https://status.example.org", Icon: "fas fa-heartbeat", Target: "_blank"},
+	}
+	if !reflect.DeepEqual(config.Links, want) {
+		t.Errorf("expected links %+v, got %+v", want, config.Links)
+	}
+	if len(config.Services) != 0 {
+		t.Errorf("expected no items for link ingresses, got %+v", config.Services)
+	}
+}
+
+func TestUpdateHomerConfigLinksOnFilteredHosts(t *testing.T) {
+	linked := func(name, host string) networkingv1.Ingress {
+		return networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "docs", Annotations: map[string]string{
+				"link.homer.rajsingh.info/name": name,
+			}},
+			Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: host}}},
+		}
+	}
+	list := networkingv1.IngressList{Items: []networkingv1.Ingress{
+		linked("wiki", "wiki.example.com"),
+		linked("canary", "wiki-canary.example.com"),
+		linked("corp", "wiki.corp.internal"),
+	}}
+	config := HomerConfig{}
+	options := DiscoveryOptions{DomainFilters: []string{"example.com"}, ExcludeHostPatterns: []string{"*-canary.*"}}
+	if err := UpdateHomerConfig(&config, list, options); err != nil {
+		t.Fatal(err)
+	}
+	want := []Link{{Name: "wiki", Url: "http://wiki.example.com"}}
+	if !reflect.DeepEqual(config.Links, want) {
+		t.Errorf("expected only the link on an allowed host %+v, got %+v", want, config.Links)
+	}
+}
+
+func TestCreateIngressItemAllowedFields(t *testing.T) {
+	ingress := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Name:      "app",
+		Namespace: "default",
+		Annotations: map[string]string{
+			"item.homer.rajsingh.info/subtitle": "Custom subtitle",
+			"item.homer.rajsingh.info/tag":      "beta",
+			"service.homer.rajsingh.info/name":  "Tools",
+			"service.homer.rajsingh.info/icon":  "fas fa-wrench",
+		},
+	}}
+	tests := []struct {
+		name       string
+		options    DiscoveryOptions
+		wantTag    string
+		wantIcon   string
+		wantLogged []string
+	}{
+		{
+			name:     "no allowlist",
+			options:  DiscoveryOptions{},
+			wantTag:  "beta",
+			wantIcon: "fas fa-wrench",
+		},
+		{
+			name: "warn keeps fields that are not allowed",
+			options: DiscoveryOptions{
+				AllowedItemFields:    []string{"Subtitle"},
+				AllowedServiceFields: []string{"name"},
+			},
+			wantTag:    "beta",
+			wantIcon:   "fas fa-wrench",
+			wantLogged: []string{"tag", "icon"},
+		},
+		{
+			name: "strict drops fields that are not allowed",
+			options: DiscoveryOptions{
+				AllowedItemFields:    []string{"Subtitle"},
+				AllowedServiceFields: []string{"name"},
+				Strict:               true,
+			},
+			wantLogged: []string{"tag", "icon"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logged []string
+			tt.options.Logger = funcr.New(func(_, args string) { logged = append(logged, args) }, funcr.Options{})
+			service, item := createIngressItem(ingress, "app.example.com", tt.options)
+			if item.Subtitle != "Custom subtitle" || service.Name != "Tools" {
+				t.Errorf("expected the allowed fields to be set, got subtitle %q and service %q",
+					item.Subtitle, service.Name)
+			}
+			if item.Tag != tt.wantTag || service.Icon != tt.wantIcon {
+				t.Errorf("expected tag %q and icon %q, got %q and %q", tt.wantTag, tt.wantIcon, item.Tag, service.Icon)
+			}
+			for _, field := range tt.wantLogged {
+				if !slices.ContainsFunc(logged, func(line string) bool {
+					return strings.Contains(line, `"field"="`+field+`"`)
+				}) {
+					t.Errorf("expected the %s field to be logged, got %q", field, logged)
+				}
+			}
+			if len(tt.wantLogged) == 0 && len(logged) > 0 {
+				t.Errorf("expected nothing logged, got %q", logged)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package homer
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DebugEntry records why one evaluated resource was included in or excluded from a
+// dashboard.
+type DebugEntry struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+}
+
+// DebugConfigMapName returns the name of the debug ConfigMap of a Dashboard.
+func DebugConfigMapName(name string) string {
+	return name + "-homer-debug"
+}
+
+// CreateDebugConfigMap renders entries as JSON into the debug.json key of a ConfigMap
+// next to the Dashboard's config.
+func CreateDebugConfigMap(entries []DebugEntry, name, namespace, instance string) (corev1.ConfigMap, error) {
+	if entries == nil {
+		entries = []DebugEntry{}
+	}
+	debug, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return corev1.ConfigMap{}, err
+	}
+	return corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DebugConfigMapName(name),
+			Namespace: namespace,
+			Labels:    resourceLabels(name, instance),
+		},
+		Data: map[string]string{
+			"debug.json": string(debug),
+		},
+	}, nil
+}
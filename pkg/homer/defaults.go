@@ -0,0 +1,38 @@
+package homer
+
+import (
+	"reflect"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// LoadHomerConfig parses a HomerConfig from YAML, rejecting unknown keys.
+func LoadHomerConfig(data string) (HomerConfig, error) {
+	config := HomerConfig{}
+	if err := yaml.UnmarshalStrict([]byte(data), &config); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+// MergeHomerConfig returns override with every field it leaves unset taken from base.
+// Nested structs and colors are merged field by field; lists such as services and
+// links are taken from base only when override has none.
+func MergeHomerConfig(base HomerConfig, override HomerConfig) HomerConfig {
+	merged := override
+	mergeUnsetFields(reflect.ValueOf(&merged).Elem(), reflect.ValueOf(base))
+	merged.Colors = MergeColors(base.Colors, override.Colors)
+	return merged
+}
+
+func mergeUnsetFields(merged reflect.Value, base reflect.Value) {
+	for i := 0; i < merged.NumField(); i++ {
+		field := merged.Field(i)
+		switch {
+		case field.Kind() == reflect.Struct:
+			mergeUnsetFields(field, base.Field(i))
+		case field.IsZero():
+			field.Set(base.Field(i))
+		}
+	}
+}
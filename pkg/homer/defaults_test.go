@@ -0,0 +1,45 @@
+package homer
+
+import "testing"
+
+func TestMergeHomerConfig(t *testing.T) {
+	base, err := LoadHomerConfig(`
+title: Company
+footer: Operated by the platform team
+logo: /assets/logo.png
+defaults:
+  layout: list
+  colorTheme: dark
+colors:
+  light:
+    highlight-primary: "#000"
+    background: "#fff"
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	override := HomerConfig{
+		Title:    "Team",
+		Defaults: DefaultConfig{ColorTheme: "light"},
+		Colors:   &ColorConfig{Light: &ThemeColors{Background: "#eee"}},
+	}
+	merged := MergeHomerConfig(base, override)
+	if merged.Title != "Team" || merged.Footer != base.Footer || merged.Logo != base.Logo {
+		t.Errorf("unexpected title, footer or logo: %q, %q, %q", merged.Title, merged.Footer, merged.Logo)
+	}
+	if merged.Defaults.Layout != "list" || merged.Defaults.ColorTheme != "light" {
+		t.Errorf("expected defaults to merge field by field, got %+v", merged.Defaults)
+	}
+	if merged.Colors.Light.HighlightPrimary != "#000" || merged.Colors.Light.Background != "#eee" {
+		t.Errorf("expected colors to merge field by field, got %+v", merged.Colors.Light)
+	}
+	if base.Title != "Company" || base.Defaults.ColorTheme != "dark" {
+		t.Errorf("expected the base config to be left untouched, got %+v", base)
+	}
+}
+
+func TestLoadHomerConfigRejectsUnknownKeys(t *testing.T) {
+	if _, err := LoadHomerConfig("titel: Company\n"); err == nil {
+		t.Error("expected an error for an unknown key")
+	}
+}
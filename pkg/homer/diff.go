@@ -0,0 +1,75 @@
+package homer
+
+import (
+	"fmt"
+	"sort"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// DiffConfigYAML compares two rendered config.yml documents and describes the service
+// groups and items added or removed, e.g. `item "grafana" added to group "monitoring"`.
+// Documents that cannot be parsed are reported as a single change.
+func DiffConfigYAML(oldYAML, newYAML string) []string {
+	oldItems, oldErr := configItems(oldYAML)
+	newItems, newErr := configItems(newYAML)
+	if oldErr != nil || newErr != nil {
+		if oldYAML == newYAML {
+			return nil
+		}
+		return []string{"config.yml changed"}
+	}
+	var changes []string
+	for _, group := range sortedKeys(oldItems, newItems) {
+		oldGroup, inOld := oldItems[group]
+		newGroup, inNew := newItems[group]
+		switch {
+		case !inOld:
+			changes = append(changes, fmt.Sprintf("group %q added", group))
+		case !inNew:
+			changes = append(changes, fmt.Sprintf("group %q removed", group))
+		}
+		for _, item := range sortedKeys(oldGroup, newGroup) {
+			switch {
+			case !oldGroup[item]:
+				changes = append(changes, fmt.Sprintf("item %q added to group %q", item, group))
+			case !newGroup[item]:
+				changes = append(changes, fmt.Sprintf("item %q removed from group %q", item, group))
+			}
+		}
+	}
+	return changes
+}
+
+// configItems returns the item names of every service group of a config.yml document.
+func configItems(configYAML string) (map[string]map[string]bool, error) {
+	var config HomerConfig
+	if err := yaml.Unmarshal([]byte(configYAML), &config); err != nil {
+		return nil, err
+	}
+	groups := map[string]map[string]bool{}
+	for _, service := range config.Services {
+		if groups[service.Name] == nil {
+			groups[service.Name] = map[string]bool{}
+		}
+		for _, item := range service.Items {
+			groups[service.Name][item.Name] = true
+		}
+	}
+	return groups, nil
+}
+
+// sortedKeys returns the keys of both maps, sorted and without duplicates.
+func sortedKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
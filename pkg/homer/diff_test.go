@@ -0,0 +1,39 @@
+package homer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffConfigYAML(t *testing.T) {
+	oldYAML := `services:
+- name: monitoring
+  items:
+  - name: prometheus
+- name: media
+  items:
+  - name: jellyfin
+`
+	newYAML := `services:
+- name: monitoring
+  items:
+  - name: prometheus
+  - name: grafana
+- name: tools
+  items:
+  - name: gitea
+`
+	want := []string{
+		`group "media" removed`,
+		`item "jellyfin" removed from group "media"`,
+		`item "grafana" added to group "monitoring"`,
+		`group "tools" added`,
+		`item "gitea" added to group "tools"`,
+	}
+	if got := DiffConfigYAML(oldYAML, newYAML); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffConfigYAML() = %q, want %q", got, want)
+	}
+	if got := DiffConfigYAML(newYAML, newYAML); len(got) != 0 {
+		t.Errorf("expected no changes, got %q", got)
+	}
+}
@@ -0,0 +1,62 @@
+package homer
+
+import (
+	"net"
+	"strconv"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+)
+
+// UpdateHomerConfigEndpointSlice adds an item for every ready endpoint address and
+// port of the slice to the service group named after the Service owning the slice.
+// Endpoints that are not ready are skipped, so they drop out on the next rebuild.
+func UpdateHomerConfigEndpointSlice(config *HomerConfig, slice discoveryv1.EndpointSlice) {
+	serviceName := slice.Labels[discoveryv1.LabelServiceName]
+	if serviceName == "" {
+		serviceName = slice.Name
+	}
+	for _, endpoint := range slice.Endpoints {
+		if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+			continue
+		}
+		name := serviceName
+		switch {
+		case endpoint.Hostname != nil:
+			name = *endpoint.Hostname
+		case endpoint.TargetRef != nil:
+			name = endpoint.TargetRef.Name
+		}
+		for _, address := range endpoint.Addresses {
+			for _, port := range slice.Ports {
+				if port.Port == nil {
+					continue
+				}
+				item := Item{
+					Name:       name,
+					Subtitle:   address,
+					Url:        "http://" + net.JoinHostPort(address, strconv.Itoa(int(*port.Port))),
+					Source:     "endpointslice/" + slice.Namespace + "/" + slice.Name,
+					LastUpdate: LastUpdate(&slice),
+				}
+				if port.Name != nil && *port.Name != "" {
+					item.Name = name + " (" + *port.Name + ")"
+				}
+				addServiceItem(config, serviceName, item)
+			}
+		}
+	}
+}
+
+// addServiceItem appends item to the service group called serviceName, creating
+// the group if the config does not have one yet. Frozen groups are left alone.
+func addServiceItem(config *HomerConfig, serviceName string, item Item) {
+	for i, service := range config.Services {
+		if service.Name == serviceName {
+			if !service.Frozen {
+				config.Services[i].Items = append(service.Items, item)
+			}
+			return
+		}
+	}
+	config.Services = append(config.Services, Service{Name: serviceName, Items: []Item{item}})
+}
@@ -0,0 +1,35 @@
+package homer
+
+import (
+	"testing"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUpdateHomerConfigEndpointSliceSkipsNotReady(t *testing.T) {
+	ready, notReady := true, false
+	hostA, hostB := "tool-0", "tool-1"
+	port := int32(8080)
+	slice := discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tool-abcde",
+			Namespace: "internal",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "tool"},
+		},
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"10.0.0.1"}, Hostname: &hostA, Conditions: discoveryv1.EndpointConditions{Ready: &ready}},
+			{Addresses: []string{"10.0.0.2"}, Hostname: &hostB, Conditions: discoveryv1.EndpointConditions{Ready: &notReady}},
+		},
+		Ports: []discoveryv1.EndpointPort{{Port: &port}},
+	}
+	config := HomerConfig{}
+	UpdateHomerConfigEndpointSlice(&config, slice)
+	if len(config.Services) != 1 || config.Services[0].Name != "tool" {
+		t.Fatalf("expected a single tool service, got %+v", config.Services)
+	}
+	items := config.Services[0].Items
+	if len(items) != 1 || items[0].Name != "tool-0" || items[0].Url != "http://10.0.0.1:8080" {
+		t.Errorf("unexpected items: %+v", items)
+	}
+}
@@ -0,0 +1,105 @@
+package homer
+
+import (
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/rajsinghtech/homer-operator.git/pkg/utils"
+)
+
+// acmeSolverLabel is set by cert-manager on the ingresses it creates for HTTP-01 challenges.
+const acmeSolverLabel = "acme.cert-manager.io/http01-solver"
+
+// IngressEvaluation records how an ingress was judged against the discovery filters.
+type IngressEvaluation struct {
+	// NameAllowed is false when the ingress name matches one of the ExcludeNamePatterns.
+	NameAllowed bool
+	// IngressClassMatch is true when the ingress class matches the IngressClassName.
+	IngressClassMatch bool
+	// DomainFiltersMatch is true when a host of the ingress matches the DomainFilters.
+	DomainFiltersMatch bool
+	// HostsAllowed is false when every host matching the DomainFilters also matches one
+	// of the ExcludeHostPatterns.
+	HostsAllowed bool
+	// ACMESolverAllowed is false for the transient ingresses cert-manager creates to solve
+	// HTTP-01 challenges, unless IncludeACMESolvers is set.
+	ACMESolverAllowed bool
+	// Visible is false when the visibility window of the ingress does not include the
+	// time it was evaluated at.
+	Visible bool
+	// Hosts are the discovered hosts that passed the DomainFilters and ExcludeHostPatterns.
+	Hosts []string
+}
+
+// Included reports whether the ingress passed every filter.
+func (e IngressEvaluation) Included() bool {
+	return e.NameAllowed && e.IngressClassMatch && e.DomainFiltersMatch && e.HostsAllowed &&
+		e.ACMESolverAllowed && e.Visible
+}
+
+// Reason describes the first filter that excluded the ingress, or "included".
+func (e IngressEvaluation) Reason() string {
+	switch {
+	case !e.NameAllowed:
+		return "name matches the exclude name patterns"
+	case !e.IngressClassMatch:
+		return "ingress class does not match"
+	case !e.DomainFiltersMatch:
+		return "no host matches the domain filters"
+	case !e.HostsAllowed:
+		return "every host matches the exclude host patterns"
+	case !e.ACMESolverAllowed:
+		return "cert-manager ACME solver ingress"
+	case !e.Visible:
+		return "hidden outside its visibility window"
+	default:
+		return "included"
+	}
+}
+
+// EvaluateIngress runs the discovery filters of options on the ingress. The visibility
+// window is checked against now; a zero now leaves it to ApplyVisibilityWindows, which
+// has to see the hidden items to requeue when they appear.
+func EvaluateIngress(ingress networkingv1.Ingress, options DiscoveryOptions, now time.Time) IngressEvaluation {
+	evaluation := IngressEvaluation{
+		NameAllowed:       !utils.MatchesNamePatterns(ingress.Name, options.ExcludeNamePatterns),
+		IngressClassMatch: matchesIngressClass(options.IngressClassName, ingress),
+		ACMESolverAllowed: options.IncludeACMESolvers || ingress.Labels[acmeSolverLabel] != "true",
+		Visible:           now.IsZero() || isIngressVisible(ingress, now),
+	}
+	matched := 0
+	for _, host := range discoveredHosts(ingress, options) {
+		if !utils.MatchesHostDomainFilters(host, options.DomainFilters) {
+			continue
+		}
+		matched++
+		if !utils.MatchesAnyDomainFilter(host, options.ExcludeHostPatterns) {
+			evaluation.Hosts = append(evaluation.Hosts, host)
+		}
+	}
+	evaluation.DomainFiltersMatch = len(options.DomainFilters) == 0 || matched > 0
+	evaluation.HostsAllowed = matched == 0 || len(evaluation.Hosts) > 0
+	return evaluation
+}
+
+// matchesIngressClass checks the ingress class against className, falling back to
+// the legacy kubernetes.io/ingress.class annotation. An empty className matches all.
+func matchesIngressClass(className string, ingress networkingv1.Ingress) bool {
+	if className == "" {
+		return true
+	}
+	if ingress.Spec.IngressClassName != nil {
+		return *ingress.Spec.IngressClassName == className
+	}
+	return ingress.Annotations["kubernetes.io/ingress.class"] == className
+}
+
+// isIngressVisible reports whether now is inside the visibility window set by the
+// visible-from and visible-until annotations of the ingress. Invalid times are ignored.
+func isIngressVisible(ingress networkingv1.Ingress, now time.Time) bool {
+	item := Item{}
+	item.VisibleFrom, _ = time.Parse(time.RFC3339, ingress.Annotations["item.homer.rajsingh.info/visible-from"])
+	item.VisibleUntil, _ = time.Parse(time.RFC3339, ingress.Annotations["item.homer.rajsingh.info/visible-until"])
+	return isItemVisible(item, now)
+}
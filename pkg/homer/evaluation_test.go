@@ -0,0 +1,93 @@
+package homer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEvaluateIngress(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	nginx, traefik := "nginx", "traefik"
+	ingress := func(name string, hosts ...string) networkingv1.Ingress {
+		ingress := networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "apps"},
+			Spec:       networkingv1.IngressSpec{IngressClassName: &nginx},
+		}
+		for _, host := range hosts {
+			ingress.Spec.Rules = append(ingress.Spec.Rules, networkingv1.IngressRule{Host: host})
+		}
+		return ingress
+	}
+	classed := ingress("classed", "classed.example.com")
+	classed.Spec.IngressClassName = &traefik
+	solver := ingress("cm-acme-http-solver-abcde", "solver.example.com")
+	solver.Labels = map[string]string{acmeSolverLabel: "true"}
+	hidden := ingress("hidden", "hidden.example.com")
+	hidden.Annotations = map[string]string{"item.homer.rajsingh.info/visible-from": "2024-12-01T00:00:00Z"}
+
+	options := DiscoveryOptions{
+		DomainFilters:       []string{"example.com"},
+		ExcludeHostPatterns: []string{"*-canary.example.com"},
+		ExcludeNamePatterns: []string{"*-tmp"},
+		IngressClassName:    "nginx",
+	}
+	tests := []struct {
+		name    string
+		ingress networkingv1.Ingress
+		now     time.Time
+		reason  string
+		hosts   []string
+	}{
+		{"included", ingress("app", "app.example.com", "app-canary.example.com"), now, "included",
+			[]string{"app.example.com"}},
+		{"excluded name", ingress("app-tmp", "app.example.com"), now, "name matches the exclude name patterns", nil},
+		{"other class", classed, now, "ingress class does not match", nil},
+		{"other domain", ingress("corp", "corp.internal"), now, "no host matches the domain filters", nil},
+		{"excluded hosts", ingress("canary", "app-canary.example.com"), now,
+			"every host matches the exclude host patterns", nil},
+		{"acme solver", solver, now, "cert-manager ACME solver ingress", nil},
+		{"hidden", hidden, now, "hidden outside its visibility window", nil},
+		{"visibility left out", hidden, time.Time{}, "included", []string{"hidden.example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluation := EvaluateIngress(tt.ingress, options, tt.now)
+			if evaluation.Reason() != tt.reason {
+				t.Errorf("expected reason %q, got %q", tt.reason, evaluation.Reason())
+			}
+			if evaluation.Included() != (tt.reason == "included") {
+				t.Errorf("expected Included to be %t", tt.reason == "included")
+			}
+			if tt.hosts != nil && !reflect.DeepEqual(evaluation.Hosts, tt.hosts) {
+				t.Errorf("expected hosts %v, got %v", tt.hosts, evaluation.Hosts)
+			}
+		})
+	}
+}
+
+func TestUpdateHomerConfigUsesEvaluateIngress(t *testing.T) {
+	excluded := networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-tmp", Namespace: "apps"},
+		Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: "app.example.com"}}},
+	}
+	canary := networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "canary", Namespace: "apps"},
+		Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{Host: "app-canary.example.com"}}},
+	}
+	options := DiscoveryOptions{
+		ExcludeHostPatterns: []string{"*-canary.example.com"},
+		ExcludeNamePatterns: []string{"*-tmp"},
+	}
+	config := HomerConfig{}
+	list := networkingv1.IngressList{Items: []networkingv1.Ingress{excluded, canary}}
+	if err := UpdateHomerConfig(&config, list, options); err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Services) != 0 {
+		t.Errorf("expected the excluded ingresses to add no items, got %+v", config.Services)
+	}
+}
@@ -0,0 +1,78 @@
+package homer
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rajsinghtech/homer-operator.git/pkg/utils"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IngressRoute is the part of a Traefik IngressRoute that items are built from.
+type IngressRoute struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+	// Matches are the match expressions of the routes, e.g. Host(`app.example.com`) && PathPrefix(`/`).
+	Matches []string
+	// TLS is true when the IngressRoute terminates TLS.
+	TLS bool
+	// LastUpdate is when the IngressRoute was last written.
+	LastUpdate time.Time
+}
+
+var (
+	hostRuleRegex = regexp.MustCompile("\\bHost\\(([^)]*)\\)")
+	hostArgRegex  = regexp.MustCompile("[`\"]([^`\"]+)[`\"]")
+)
+
+// IngressRouteHosts returns the hosts named in the Host() rules of the route's match
+// expressions, in order and without duplicates. HostRegexp() rules are not hosts and
+// are left out.
+func IngressRouteHosts(route IngressRoute) []string {
+	var hosts []string
+	seen := map[string]bool{}
+	for _, match := range route.Matches {
+		for _, rule := range hostRuleRegex.FindAllStringSubmatch(match, -1) {
+			for _, arg := range hostArgRegex.FindAllStringSubmatch(rule[1], -1) {
+				if host := strings.TrimSpace(arg[1]); host != "" && !seen[host] {
+					seen[host] = true
+					hosts = append(hosts, host)
+				}
+			}
+		}
+	}
+	return hosts
+}
+
+// UpdateHomerConfigIngressRoute adds an item for every host of the Traefik IngressRoute
+// to config. The item and service annotations are applied as they are for ingresses.
+func UpdateHomerConfigIngressRoute(config *HomerConfig, route IngressRoute, options DiscoveryOptions) {
+	if utils.MatchesNamePatterns(route.Name, options.ExcludeNamePatterns) {
+		return
+	}
+	ingress := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Name:        route.Name,
+		Namespace:   route.Namespace,
+		Labels:      route.Labels,
+		Annotations: route.Annotations,
+	}}
+	if route.TLS {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{}}
+	}
+	var services []Service
+	for _, host := range IngressRouteHosts(route) {
+		if !utils.MatchesHostDomainFilters(host, options.DomainFilters) ||
+			utils.MatchesAnyDomainFilter(host, options.ExcludeHostPatterns) {
+			continue
+		}
+		service, item := createIngressItem(ingress, host, options)
+		item.Source = "ingressroute/" + route.Namespace + "/" + route.Name
+		item.LastUpdate = route.LastUpdate
+		services = append(services, groupedServices(ingress, service, item, options)...)
+	}
+	mergeDiscoveredServices(config, services, options)
+}
@@ -0,0 +1,38 @@
+package homer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIngressRouteHosts(t *testing.T) {
+	route := IngressRoute{Matches: []string{
+		"Host(`app.example.com`) && PathPrefix(`/api`)",
+		"Host(`www.example.com`, `example.com`) || Host(\"app.example.com\")",
+		"HostRegexp(`{sub:[a-z]+}.example.com`)",
+	}}
+	want := []string{"app.example.com", "www.example.com", "example.com"}
+	if got := IngressRouteHosts(route); !reflect.DeepEqual(got, want) {
+		t.Errorf("IngressRouteHosts() = %q, want %q", got, want)
+	}
+}
+
+func TestUpdateHomerConfigIngressRoute(t *testing.T) {
+	route := IngressRoute{
+		Name:        "whoami",
+		Namespace:   "apps",
+		Annotations: map[string]string{"item.homer.rajsingh.info/Subtitle": "Who am I"},
+		Matches:     []string{"Host(`whoami.example.com`)", "Host(`whoami.internal.example.com`)"},
+		TLS:         true,
+	}
+	config := HomerConfig{}
+	UpdateHomerConfigIngressRoute(&config, route, DiscoveryOptions{ExcludeHostPatterns: []string{"internal.example.com"}})
+	if len(config.Services) != 1 || config.Services[0].Name != "apps" || len(config.Services[0].Items) != 1 {
+		t.Fatalf("unexpected services: %+v", config.Services)
+	}
+	item := config.Services[0].Items[0]
+	if item.Url != "https://whoami.example.com" || item.Subtitle != "Who am I" ||
+		item.Source != "ingressroute/apps/whoami" {
+		t.Errorf("unexpected item: %+v", item)
+	}
+}
@@ -0,0 +1,69 @@
+package homer
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InventoryEntry records where one discovered item of a dashboard came from.
+type InventoryEntry struct {
+	Service   string `json:"service"`
+	Item      string `json:"item"`
+	URL       string `json:"url,omitempty"`
+	Host      string `json:"host,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// Inventory lists the discovered items of config with the resource each came from,
+// in the order they appear in config.yml. Items defined in the Dashboard are left out.
+func Inventory(config HomerConfig) []InventoryEntry {
+	entries := []InventoryEntry{}
+	for _, service := range config.Services {
+		for _, item := range service.Items {
+			if item.Source == "" {
+				continue
+			}
+			entry := InventoryEntry{Service: service.Name, Item: item.Name, URL: item.Url}
+			parts := strings.SplitN(item.Source, "/", 3)
+			entry.Kind = parts[0]
+			if len(parts) == 3 {
+				entry.Namespace, entry.Name = parts[1], parts[2]
+			}
+			if parsed, err := url.Parse(item.Url); err == nil {
+				entry.Host = parsed.Hostname()
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// InventoryConfigMapName returns the name of the inventory ConfigMap of a Dashboard.
+func InventoryConfigMapName(name string) string {
+	return name + "-homer-inventory"
+}
+
+// CreateInventoryConfigMap renders the inventory of config as JSON into the
+// inventory.json key of a ConfigMap next to the Dashboard's config.
+func CreateInventoryConfigMap(config HomerConfig, name, namespace, instance string) (corev1.ConfigMap, error) {
+	inventory, err := json.MarshalIndent(Inventory(config), "", "  ")
+	if err != nil {
+		return corev1.ConfigMap{}, err
+	}
+	return corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      InventoryConfigMapName(name),
+			Namespace: namespace,
+			Labels:    resourceLabels(name, instance),
+		},
+		Data: map[string]string{
+			"inventory.json": string(inventory),
+		},
+	}, nil
+}
@@ -0,0 +1,26 @@
+package homer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInventory(t *testing.T) {
+	config := HomerConfig{Services: []Service{{
+		Name: "default",
+		Items: []Item{
+			{Name: "static", Url: "https://static.example.com"},
+			{Name: "app", Url: "https://app.example.com:8443/ui", Source: "ingress/default/app"},
+			{Name: "api", Url: "http://10.0.0.1", Source: "endpointslice/default/api-x7k2p"},
+		},
+	}}}
+	want := []InventoryEntry{
+		{Service: "default", Item: "app", URL: "https://app.example.com:8443/ui", Host: "app.example.com",
+			Kind: "ingress", Namespace: "default", Name: "app"},
+		{Service: "default", Item: "api", URL: "http://10.0.0.1", Host: "10.0.0.1",
+			Kind: "endpointslice", Namespace: "default", Name: "api-x7k2p"},
+	}
+	if got := Inventory(config); !reflect.DeepEqual(got, want) {
+		t.Errorf("Inventory() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,117 @@
+package homer
+
+import "github.com/go-logr/logr"
+
+const (
+	itemUnplaced = iota
+	itemPlacing
+	itemPlaced
+)
+
+// itemOrder places the items of one service group so that each item comes after the
+// items it must follow, and an item with an after hint right after the named sibling.
+type itemOrder struct {
+	items []Item
+	// predecessors are the items that must come before each item.
+	predecessors [][]int
+	// successors are the items placed right after each item by their after hint.
+	successors [][]int
+	state      []int
+	ordered    []Item
+}
+
+// OrderServiceItems reorders the items of each service group by the after and before
+// hints of discovered items, which name a sibling in the same group. Hints naming a
+// missing item are logged and ignored; a group with conflicting hints is logged and
+// keeps its order.
+func OrderServiceItems(config *HomerConfig, logger logr.Logger) {
+	for i, service := range config.Services {
+		config.Services[i].Items = orderItems(service, logger)
+	}
+}
+
+// orderItems returns the items of service in hint order. Items without hints keep their
+// relative order.
+func orderItems(service Service, logger logr.Logger) []Item {
+	items := service.Items
+	byName := map[string][]int{}
+	for i, item := range items {
+		byName[item.Name] = append(byName[item.Name], i)
+	}
+	order := &itemOrder{
+		items:        items,
+		predecessors: make([][]int, len(items)),
+		successors:   make([][]int, len(items)),
+		state:        make([]int, len(items)),
+	}
+	hinted := false
+	for i, item := range items {
+		for k, sibling := range []string{item.After, item.Before} {
+			if sibling == "" {
+				continue
+			}
+			if _, ok := byName[sibling]; !ok {
+				logger.Info("Ignoring ordering hint naming a missing item", "service", service.Name,
+					"item", item.Name, "sibling", sibling)
+				continue
+			}
+			for _, j := range byName[sibling] {
+				if j == i {
+					continue
+				}
+				hinted = true
+				if k == 0 {
+					order.predecessors[i] = append(order.predecessors[i], j)
+					order.successors[j] = append(order.successors[j], i)
+				} else {
+					order.predecessors[j] = append(order.predecessors[j], i)
+				}
+			}
+		}
+	}
+	if !hinted {
+		return items
+	}
+	for i := range items {
+		if !order.place(i) {
+			logger.Info("Ignoring conflicting ordering hints", "service", service.Name)
+			return items
+		}
+	}
+	return order.ordered
+}
+
+// place appends item i after its predecessors, followed by the items hinted to come right
+// after it. It reports false when the hints form a cycle.
+func (o *itemOrder) place(i int) bool {
+	switch o.state[i] {
+	case itemPlaced:
+		return true
+	case itemPlacing:
+		return false
+	}
+	o.state[i] = itemPlacing
+	for _, j := range o.predecessors[i] {
+		if !o.place(j) {
+			return false
+		}
+	}
+	o.state[i] = itemPlaced
+	o.ordered = append(o.ordered, o.items[i])
+	for _, j := range o.successors[i] {
+		if o.state[j] == itemUnplaced && o.placed(o.predecessors[j]) && !o.place(j) {
+			return false
+		}
+	}
+	return true
+}
+
+// placed reports whether all of items are placed already.
+func (o *itemOrder) placed(items []int) bool {
+	for _, i := range items {
+		if o.state[i] != itemPlaced {
+			return false
+		}
+	}
+	return true
+}
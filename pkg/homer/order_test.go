@@ -0,0 +1,86 @@
+package homer
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func itemNames(items []Item) []string {
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	return names
+}
+
+func TestOrderServiceItems(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []Item
+		want  []string
+	}{
+		{
+			name:  "no hints",
+			items: []Item{{Name: "b"}, {Name: "a"}},
+			want:  []string{"b", "a"},
+		},
+		{
+			name:  "after moves the item right after its sibling",
+			items: []Item{{Name: "docs", After: "app"}, {Name: "app"}, {Name: "api"}},
+			want:  []string{"app", "docs", "api"},
+		},
+		{
+			name:  "after a later sibling",
+			items: []Item{{Name: "app"}, {Name: "api"}, {Name: "wiki"}, {Name: "docs", After: "app"}},
+			want:  []string{"app", "docs", "api", "wiki"},
+		},
+		{
+			name:  "before moves the item ahead of its sibling",
+			items: []Item{{Name: "app"}, {Name: "api"}, {Name: "status", Before: "app"}},
+			want:  []string{"status", "app", "api"},
+		},
+		{
+			name:  "chained hints",
+			items: []Item{{Name: "c", After: "b"}, {Name: "b", After: "a"}, {Name: "x"}, {Name: "a"}},
+			want:  []string{"a", "b", "c", "x"},
+		},
+		{
+			name:  "missing sibling is ignored",
+			items: []Item{{Name: "docs", After: "ghost"}, {Name: "app"}},
+			want:  []string{"docs", "app"},
+		},
+		{
+			name:  "conflicting hints keep the order",
+			items: []Item{{Name: "a", After: "b"}, {Name: "b", After: "a"}, {Name: "c"}},
+			want:  []string{"a", "b", "c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := HomerConfig{Services: []Service{{Name: "apps", Items: tt.items}}}
+			OrderServiceItems(&config, logr.Discard())
+			if got := itemNames(config.Services[0].Items); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateIngressItemOrderingHints(t *testing.T) {
+	ingress := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Name:      "docs",
+		Namespace: "default",
+		Annotations: map[string]string{
+			"item.homer.rajsingh.info/after":  "app",
+			"item.homer.rajsingh.info/before": "wiki",
+		},
+	}}
+	_, item := createIngressItem(ingress, "docs.example.com", DiscoveryOptions{})
+	if item.After != "app" || item.Before != "wiki" {
+		t.Errorf("expected the ordering hints, got after %q and before %q", item.After, item.Before)
+	}
+}
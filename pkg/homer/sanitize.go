@@ -0,0 +1,20 @@
+package homer
+
+import "github.com/microcosm-cc/bluemonday"
+
+// footerPolicy allows the formatting, links, and images of user generated content plus
+// class attributes, which Homer footers use for Bulma styling. Everything else, scripts,
+// event handlers, SVG and MathML included, is removed, and URLs are limited to http,
+// https, and mailto.
+var footerPolicy = func() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.RequireNoFollowOnLinks(false)
+	policy.AllowAttrs("class").Globally()
+	return policy
+}()
+
+// sanitizeHTML reduces HTML that Homer renders as-is, such as the footer, to the
+// elements and attributes allowed by footerPolicy.
+func sanitizeHTML(html string) string {
+	return footerPolicy.Sanitize(html)
+}
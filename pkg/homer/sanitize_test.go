@@ -0,0 +1,53 @@
+package homer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: `<p>Homer-Operator</p>`, want: `<p>Homer-Operator</p>`},
+		{
+			in:   `<p class="has-text-centered">Made with <a href="https://bulma.io/">Bulma</a></p>`,
+			want: `<p class="has-text-centered">Made with <a href="https://bulma.io/">Bulma</a></p>`,
+		},
+		{in: `<p>a</p><script>alert(1)</script><p>b</p>`, want: `<p>a</p><p>b</p>`},
+		{in: `<p>a</p><SCRIPT src="x.js">`, want: `<p>a</p>`},
+		{in: `<img src="logo.png" onerror="alert(1)">`, want: `<img src="logo.png">`},
+		{in: `<a href="javascript:alert(1)">x</a>`, want: `x`},
+	}
+	for _, tt := range tests {
+		if got := sanitizeHTML(tt.in); got != tt.want {
+			t.Errorf("sanitizeHTML(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSanitizeHTMLObfuscatedPayloads(t *testing.T) {
+	payloads := []string{
+		`<a href="jav&#x09;ascript:alert(1)">x</a>`,
+		`<a href="&#106;avascript:alert(1)">x</a>`,
+		`<a href="  JaVaScRiPt:alert(1)">x</a>`,
+		`<a href=javascript:alert(1)>x</a>`,
+		`<a href="data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==">x</a>`,
+		`<img src=x onerror=alert(1)>`,
+		`<img src="x"/onerror="alert(1)">`,
+		`<svg onload=alert(1)><circle r="1"/></svg>`,
+		`<svg><a xlink:href="javascript:alert(1)"><text>x</text></a></svg>`,
+		`<math><mtext><table><mglyph><style><img src=x onerror=alert(1)></style></mglyph></table></mtext></math>`,
+		`<iframe src="https://example.com"></iframe>`,
+		`<form action="javascript:alert(1)"><button>x</button></form>`,
+	}
+	for _, payload := range payloads {
+		got := strings.ToLower(sanitizeHTML(payload))
+		for _, unsafe := range []string{"javascript", "data:", "onerror", "onload", "<svg", "<math", "<iframe", "<form"} {
+			if strings.Contains(got, unsafe) {
+				t.Errorf("sanitizeHTML(%q) = %q, which still contains %q", payload, got, unsafe)
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package homer
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	"sigs.k8s.io/yaml"
+)
+
+// homerSchema is the JSON schema of the config.yml keys and values Homer understands.
+//
+//go:embed schema.json
+var homerSchema []byte
+
+// ValidateHomerSchema checks a rendered config.yml against the Homer config schema and
+// returns a message for every key Homer does not know and every value of the wrong type.
+func ValidateHomerSchema(config []byte) ([]string, error) {
+	schema, err := loadHomerSchema()
+	if err != nil {
+		return nil, err
+	}
+	document, err := yaml.YAMLToJSON(config)
+	if err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	var data interface{}
+	if err := json.Unmarshal(document, &data); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	result := validate.NewSchemaValidator(schema, schema, "config", strfmt.Default).Validate(data)
+	problems := make([]string, 0, len(result.Errors))
+	for _, err := range result.Errors {
+		problems = append(problems, err.Error())
+	}
+	sort.Strings(problems)
+	return problems, nil
+}
+
+// loadHomerSchema parses homerSchema with its $ref entries replaced by the definitions they
+// point to, since the validator does not resolve references.
+func loadHomerSchema() (*spec.Schema, error) {
+	var document map[string]interface{}
+	if err := json.Unmarshal(homerSchema, &document); err != nil {
+		return nil, fmt.Errorf("parse Homer config schema: %w", err)
+	}
+	definitions, _ := document["definitions"].(map[string]interface{})
+	delete(document, "definitions")
+	expanded, err := json.Marshal(expandRefs(document, definitions))
+	if err != nil {
+		return nil, fmt.Errorf("parse Homer config schema: %w", err)
+	}
+	schema := &spec.Schema{}
+	if err := json.Unmarshal(expanded, schema); err != nil {
+		return nil, fmt.Errorf("parse Homer config schema: %w", err)
+	}
+	return schema, nil
+}
+
+// expandRefs returns node with every {"$ref": "#/definitions/<name>"} replaced by the
+// named definition. The definitions must not refer to themselves.
+func expandRefs(node interface{}, definitions map[string]interface{}) interface{} {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["$ref"].(string); ok {
+			return expandRefs(definitions[strings.TrimPrefix(ref, "#/definitions/")], definitions)
+		}
+		expanded := make(map[string]interface{}, len(value))
+		for key, entry := range value {
+			expanded[key] = expandRefs(entry, definitions)
+		}
+		return expanded
+	case []interface{}:
+		expanded := make([]interface{}, len(value))
+		for i, entry := range value {
+			expanded[i] = expandRefs(entry, definitions)
+		}
+		return expanded
+	}
+	return node
+}
@@ -0,0 +1,51 @@
+package homer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateHomerSchema(t *testing.T) {
+	connectivityCheck := false
+	config := HomerConfig{
+		Title:             "Dashboard",
+		Header:            "true",
+		Defaults:          DefaultConfig{Layout: "list", ColorTheme: "dark"},
+		Links:             []Link{{Name: "Docs", Url: "https://docs.example.com"}},
+		Proxy:             &ProxyConfig{UseCredentials: true, Headers: map[string]string{"X-Auth": "secret"}},
+		Colors:            &ColorConfig{Light: &ThemeColors{HighlightPrimary: "#3367d6"}},
+		Hotkey:            &HotkeyConfig{Search: "/"},
+		ConnectivityCheck: &connectivityCheck,
+		Services: []Service{{Name: "media", Layout: "list", Items: []Item{{
+			Name: "jellyfin", Icon: "fas fa-film", Tagstyle: "is-info", Legacyapi: "true", Warningvalue: "50",
+		}}}},
+	}
+	for _, options := range []RenderOptions{{}, {Minimal: true}} {
+		rendered, err := marshalHomerConfigToYAML(config, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		problems, err := ValidateHomerSchema(rendered)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(problems) > 0 {
+			t.Errorf("expected the rendered config to match the schema, got %v:\n%s", problems, rendered)
+		}
+	}
+
+	rendered, err := marshalHomerConfigToYAML(HomerConfig{
+		Title:    "Dashboard",
+		Services: []Service{{Name: "media", Layout: "grid"}},
+	}, RenderOptions{KeyOverrides: map[string]string{"title": "titel"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	problems, err := ValidateHomerSchema(rendered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 2 || !strings.Contains(strings.Join(problems, "\n"), "titel") {
+		t.Errorf("expected the unknown key and the invalid layout, got %v", problems)
+	}
+}
@@ -0,0 +1,77 @@
+package homer
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+// smartCardPaths maps the Homer smart card types to the path, relative to the
+// service URL, their endpoint conventionally points at. Homer appends the API
+// route itself, so most cards only need the service root.
+var smartCardPaths = map[string]string{
+	"AdGuardHome":      "",
+	"Emby":             "",
+	"Gitea":            "",
+	"Healthchecks":     "",
+	"Jellyfin":         "",
+	"Lidarr":           "",
+	"Mealie":           "",
+	"Medusa":           "",
+	"Octoprint":        "",
+	"Ping":             "",
+	"PiHole":           "/admin",
+	"Portainer":        "",
+	"Prometheus":       "",
+	"Prowlarr":         "",
+	"Proxmox":          "",
+	"Radarr":           "",
+	"Readarr":          "",
+	"SABnzbd":          "",
+	"Sonarr":           "",
+	"Speedtesttracker": "",
+	"Tautulli":         "",
+	"UptimeKuma":       "",
+}
+
+// applySmartCard turns the item into the named Homer smart card. The type name is
+// matched case-insensitively against the known cards, and unless an endpoint is
+// already set it is derived from the item URL.
+func applySmartCard(item *Item, cardType string) {
+	path := ""
+	for known, knownPath := range smartCardPaths {
+		if strings.EqualFold(known, cardType) {
+			cardType, path = known, knownPath
+			break
+		}
+	}
+	if item.Type == "" {
+		item.Type = cardType
+	}
+	if item.Endpoint == "" {
+		item.Endpoint = strings.TrimSuffix(item.Url, "/") + path
+	}
+}
+
+// RestrictSmartCardTypes strips the type, endpoint and API key from the items whose
+// smart card type is not in allowed, so they render as plain links. The types are
+// matched case-insensitively; an empty allowed list allows every type.
+func RestrictSmartCardTypes(config *HomerConfig, allowed []string, logger logr.Logger) {
+	if len(allowed) == 0 {
+		return
+	}
+	for i, service := range config.Services {
+		for j, item := range service.Items {
+			if item.Type == "" || slices.ContainsFunc(allowed, func(cardType string) bool {
+				return strings.EqualFold(cardType, item.Type)
+			}) {
+				continue
+			}
+			logger.Info("Stripping smart card type that is not allowed", "service", service.Name,
+				"item", item.Name, "type", item.Type)
+			item.Type, item.Endpoint, item.Apikey = "", "", ""
+			config.Services[i].Items[j] = item
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package homer
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApplySmartCard(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     Item
+		cardType string
+		want     Item
+	}{
+		{
+			name:     "known type gets its endpoint path",
+			item:     Item{Url: "https://pihole.example.com/"},
+			cardType: "pihole",
+			want:     Item{Url: "https://pihole.example.com/", Type: "PiHole", Endpoint: "https://pihole.example.com/admin"},
+		},
+		{
+			name:     "known type without a path",
+			item:     Item{Url: "https://sonarr.example.com"},
+			cardType: "Sonarr",
+			want:     Item{Url: "https://sonarr.example.com", Type: "Sonarr", Endpoint: "https://sonarr.example.com"},
+		},
+		{
+			name:     "unknown type is passed through",
+			item:     Item{Url: "https://custom.example.com"},
+			cardType: "MyCard",
+			want:     Item{Url: "https://custom.example.com", Type: "MyCard", Endpoint: "https://custom.example.com"},
+		},
+		{
+			name:     "existing type and endpoint are kept",
+			item:     Item{Url: "https://pihole.example.com", Type: "Ping", Endpoint: "http://pihole:8080"},
+			cardType: "pihole",
+			want:     Item{Url: "https://pihole.example.com", Type: "Ping", Endpoint: "http://pihole:8080"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := tt.item
+			applySmartCard(&item, tt.cardType)
+			if item != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, item)
+			}
+		})
+	}
+}
+
+func TestCreateIngressItemSmartCard(t *testing.T) {
+	ingress := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Name:        "pihole",
+		Namespace:   "dns",
+		Annotations: map[string]string{"item.homer.rajsingh.info/smart-card": "PIHOLE"},
+	}}
+	_, item := createIngressItem(ingress, "pihole.example.com", DiscoveryOptions{})
+	if item.Type != "PiHole" || item.Endpoint != item.Url+"/admin" {
+		t.Errorf("expected a PiHole card with the /admin endpoint, got type %q endpoint %q (url %q)",
+			item.Type, item.Endpoint, item.Url)
+	}
+
+	ingress.Annotations["item.homer.rajsingh.info/endpoint"] = "http://pihole.dns.svc"
+	_, item = createIngressItem(ingress, "pihole.example.com", DiscoveryOptions{})
+	if item.Type != "PiHole" || item.Endpoint != "http://pihole.dns.svc" {
+		t.Errorf("expected the annotated endpoint to be kept, got type %q endpoint %q", item.Type, item.Endpoint)
+	}
+
+	_, item = createIngressItem(ingress, "pihole.example.com", DiscoveryOptions{
+		AllowedItemFields: []string{"Name"}, Strict: true, Logger: logr.Discard(),
+	})
+	if item.Type != "" {
+		t.Errorf("expected no smart card when Type is not an allowed field in strict mode, got %q", item.Type)
+	}
+}
+
+func TestRestrictSmartCardTypes(t *testing.T) {
+	config := HomerConfig{Services: []Service{{Name: "apps", Items: []Item{
+		{Name: "pihole", Type: "PiHole", Endpoint: "https://pihole/admin", Apikey: "secret"},
+		{Name: "probe", Type: "Prometheus", Endpoint: "http://internal:9090", Apikey: "secret"},
+		{Name: "plain", Url: "https://plain"},
+	}}}}
+	RestrictSmartCardTypes(&config, []string{"pihole"}, logr.Discard())
+	items := config.Services[0].Items
+	if items[0].Type != "PiHole" || items[0].Endpoint == "" || items[0].Apikey == "" {
+		t.Errorf("expected the allowed card to be kept, got %+v", items[0])
+	}
+	if items[1].Type != "" || items[1].Endpoint != "" || items[1].Apikey != "" {
+		t.Errorf("expected the card that is not allowed to be stripped, got %+v", items[1])
+	}
+	if items[1].Name != "probe" || items[2].Url != "https://plain" {
+		t.Errorf("expected the rest of the items to be kept, got %+v", items)
+	}
+
+	config = HomerConfig{Services: []Service{{Name: "apps", Items: []Item{{Name: "probe", Type: "Prometheus"}}}}}
+	RestrictSmartCardTypes(&config, nil, logr.Discard())
+	if config.Services[0].Items[0].Type != "Prometheus" {
+		t.Errorf("expected every type to be allowed without a list, got %+v", config.Services[0].Items[0])
+	}
+}
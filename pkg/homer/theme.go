@@ -0,0 +1,131 @@
+package homer
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+type ColorConfig struct {
+	Light *ThemeColors `json:"light,omitempty" yaml:"light,omitempty"`
+	Dark  *ThemeColors `json:"dark,omitempty" yaml:"dark,omitempty"`
+}
+
+type ThemeColors struct {
+	HighlightPrimary   string `json:"highlight-primary,omitempty" yaml:"highlight-primary,omitempty"`
+	HighlightSecondary string `json:"highlight-secondary,omitempty" yaml:"highlight-secondary,omitempty"`
+	HighlightHover     string `json:"highlight-hover,omitempty" yaml:"highlight-hover,omitempty"`
+	Background         string `json:"background,omitempty" yaml:"background,omitempty"`
+	CardBackground     string `json:"card-background,omitempty" yaml:"card-background,omitempty"`
+	Text               string `json:"text,omitempty" yaml:"text,omitempty"`
+	TextHeader         string `json:"text-header,omitempty" yaml:"text-header,omitempty"`
+	TextTitle          string `json:"text-title,omitempty" yaml:"text-title,omitempty"`
+	TextSubtitle       string `json:"text-subtitle,omitempty" yaml:"text-subtitle,omitempty"`
+	CardShadow         string `json:"card-shadow,omitempty" yaml:"card-shadow,omitempty"`
+	Link               string `json:"link,omitempty" yaml:"link,omitempty"`
+	LinkHover          string `json:"link-hover,omitempty" yaml:"link-hover,omitempty"`
+	BackgroundImage    string `json:"background-image,omitempty" yaml:"background-image,omitempty"`
+}
+
+// ThemeConfig is the content of a theme ConfigMap key: a Homer theme name and its colors.
+type ThemeConfig struct {
+	Theme  string       `yaml:"theme,omitempty"`
+	Colors *ColorConfig `yaml:"colors,omitempty"`
+}
+
+var colorFunctionRegex = regexp.MustCompile(`^(rgb|rgba|hsl|hsla)\([0-9.,%\s]+\)$`)
+
+// LoadThemeConfig parses a theme and colors from YAML.
+func LoadThemeConfig(data string) (ThemeConfig, error) {
+	theme := ThemeConfig{}
+	if err := yaml.UnmarshalStrict([]byte(data), &theme); err != nil {
+		return theme, err
+	}
+	return theme, nil
+}
+
+// MergeColors returns base with every color set in override replacing the base value.
+func MergeColors(base *ColorConfig, override *ColorConfig) *ColorConfig {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+	return &ColorConfig{
+		Light: mergeThemeColors(base.Light, override.Light),
+		Dark:  mergeThemeColors(base.Dark, override.Dark),
+	}
+}
+
+func mergeThemeColors(base *ThemeColors, override *ThemeColors) *ThemeColors {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+	merged := *base
+	mergedValue := reflect.ValueOf(&merged).Elem()
+	overrideValue := reflect.ValueOf(override).Elem()
+	for i := 0; i < overrideValue.NumField(); i++ {
+		if value := overrideValue.Field(i).String(); value != "" {
+			mergedValue.Field(i).SetString(value)
+		}
+	}
+	return &merged
+}
+
+// ValidateColors checks every color of the config with isValidColor.
+// The background image is a URL rather than a color and is not checked.
+func ValidateColors(colors *ColorConfig) error {
+	if colors == nil {
+		return nil
+	}
+	modes := []struct {
+		name   string
+		colors *ThemeColors
+	}{{"light", colors.Light}, {"dark", colors.Dark}}
+	for _, mode := range modes {
+		themeColors := mode.colors
+		if themeColors == nil {
+			continue
+		}
+		value := reflect.ValueOf(themeColors).Elem()
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			color := value.Field(i).String()
+			if field.Name == "BackgroundImage" || color == "" {
+				continue
+			}
+			if !isValidColor(color) {
+				key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+				return fmt.Errorf("invalid color %q for colors.%s.%s", color, mode.name, key)
+			}
+		}
+	}
+	return nil
+}
+
+// isValidColor accepts #rgb, #rgba, #rrggbb and #rrggbbaa hex colors as well as
+// rgb(), rgba(), hsl() and hsla() functions.
+func isValidColor(color string) bool {
+	if strings.HasPrefix(color, "#") {
+		hex := color[1:]
+		switch len(hex) {
+		case 3, 4, 6, 8:
+		default:
+			return false
+		}
+		for _, c := range hex {
+			if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+				return false
+			}
+		}
+		return true
+	}
+	return colorFunctionRegex.MatchString(color)
+}
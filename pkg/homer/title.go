@@ -0,0 +1,51 @@
+package homer
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// TitleData is what the title and subtitle templates of a config are rendered with.
+type TitleData struct {
+	// ClusterName is the name the operator was started with in --cluster-name.
+	ClusterName string
+	// Name and Namespace are those of the Dashboard.
+	Name      string
+	Namespace string
+	// Services and Items count the service groups and items of the config.
+	Services int
+	Items    int
+}
+
+// RenderTitles renders the title and subtitle of config as Go templates over data,
+// e.g. "Apps on {{ .ClusterName }}". Text without a template action is left as is.
+func RenderTitles(config *HomerConfig, data TitleData) error {
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{{"title", &config.Title}, {"subtitle", &config.Subtitle}} {
+		if !strings.Contains(*field.value, "{{") {
+			continue
+		}
+		titleTemplate, err := template.New(field.name).Parse(*field.value)
+		if err != nil {
+			return fmt.Errorf("invalid %s template: %w", field.name, err)
+		}
+		var rendered strings.Builder
+		if err := titleTemplate.Execute(&rendered, data); err != nil {
+			return fmt.Errorf("unable to render the %s: %w", field.name, err)
+		}
+		*field.value = rendered.String()
+	}
+	return nil
+}
+
+// NewTitleData counts the services and items of config for rendering its titles.
+func NewTitleData(config HomerConfig, clusterName, name, namespace string) TitleData {
+	data := TitleData{ClusterName: clusterName, Name: name, Namespace: namespace, Services: len(config.Services)}
+	for _, service := range config.Services {
+		data.Items += len(service.Items)
+	}
+	return data
+}
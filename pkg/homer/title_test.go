@@ -0,0 +1,35 @@
+package homer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTitles(t *testing.T) {
+	config := HomerConfig{
+		Title:    "Apps on {{ .ClusterName }}",
+		Subtitle: "{{ .Items }} items in {{ .Services }} groups of {{ .Namespace }}/{{ .Name }}",
+		Services: []Service{{Name: "a", Items: []Item{{}, {}}}, {Name: "b", Items: []Item{{}}}},
+	}
+	if err := RenderTitles(&config, NewTitleData(config, "prod-eu", "dashboard", "default")); err != nil {
+		t.Fatal(err)
+	}
+	if config.Title != "Apps on prod-eu" {
+		t.Errorf("unexpected title %q", config.Title)
+	}
+	if config.Subtitle != "3 items in 2 groups of default/dashboard" {
+		t.Errorf("unexpected subtitle %q", config.Subtitle)
+	}
+}
+
+func TestRenderTitlesErrors(t *testing.T) {
+	config := HomerConfig{Title: "{{ .Cluster }}"}
+	err := RenderTitles(&config, TitleData{})
+	if err == nil || !strings.Contains(err.Error(), "title") {
+		t.Errorf("expected an error naming the title, got %v", err)
+	}
+	config = HomerConfig{Subtitle: "{{ .Items "}
+	if err := RenderTitles(&config, TitleData{}); err == nil {
+		t.Error("expected an error for an unterminated action")
+	}
+}
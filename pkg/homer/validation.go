@@ -0,0 +1,157 @@
+package homer
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ValidateHomerConfig checks a rendered config for problems that do not prevent it
+// from being served but likely surprise the user, and returns them as warnings.
+func ValidateHomerConfig(config *HomerConfig) []string {
+	var warnings []string
+	if config.Title == "" && config.ExternalConfig == "" {
+		warnings = append(warnings, "title is empty")
+	}
+	warnings = append(warnings, findDuplicateItems(config)...)
+	if config.ExternalConfig != "" && !isValidURL(config.ExternalConfig) {
+		warnings = append(warnings, fmt.Sprintf("externalConfig %q is not an http(s) URL", config.ExternalConfig))
+	}
+	if config.Hotkey != nil && !isValidHotkey(config.Hotkey.Search) {
+		warnings = append(warnings, fmt.Sprintf("hotkey.search %q is not a single key", config.Hotkey.Search))
+	}
+	if !isValidLayout(config.Defaults.Layout) {
+		warnings = append(warnings, fmt.Sprintf("defaults.layout %q is neither columns nor list", config.Defaults.Layout))
+	}
+	if !isValidColorTheme(config.Defaults.ColorTheme) {
+		warnings = append(warnings, fmt.Sprintf("defaults.colorTheme %q is not one of auto, light or dark",
+			config.Defaults.ColorTheme))
+	}
+	for _, service := range config.Services {
+		if !isValidLayout(service.Layout) {
+			warnings = append(warnings, fmt.Sprintf("service %q: layout %q is neither columns nor list",
+				service.Name, service.Layout))
+		}
+		for _, item := range service.Items {
+			if !isValidItemStyle("class", item.Class) {
+				warnings = append(warnings, fmt.Sprintf("service %q: item %q has an invalid class %q",
+					service.Name, item.Name, item.Class))
+			}
+			if !isValidItemStyle("background", item.Background) {
+				warnings = append(warnings, fmt.Sprintf("service %q: item %q has an invalid background %q",
+					service.Name, item.Name, item.Background))
+			}
+			if !isValidTagStyle(item.Tagstyle) {
+				warnings = append(warnings, fmt.Sprintf("service %q: item %q has an invalid tagstyle %q",
+					service.Name, item.Name, item.Tagstyle))
+			}
+		}
+	}
+	return warnings
+}
+
+// isValidLayout reports whether layout is empty or one of the layouts Homer knows.
+func isValidLayout(layout string) bool {
+	return layout == "" || layout == "columns" || layout == "list"
+}
+
+// isValidColorTheme reports whether theme is empty or one of the color themes Homer knows.
+func isValidColorTheme(theme string) bool {
+	return theme == "" || theme == "auto" || theme == "light" || theme == "dark"
+}
+
+// isValidItemStyle reports whether value is empty or valid for the class, background, or
+// tagstyle of an item. A class is a space separated list of CSS class names. A background
+// is a color accepted by isValidColor, a named color such as "teal", or an http(s) URL.
+// A tagstyle must be accepted by isValidTagStyle.
+func isValidItemStyle(field, value string) bool {
+	if value == "" {
+		return true
+	}
+	if field == "tagstyle" {
+		return isValidTagStyle(value)
+	}
+	if field == "class" {
+		for _, class := range strings.Fields(value) {
+			if !cssClassRegex.MatchString(class) {
+				return false
+			}
+		}
+		return true
+	}
+	return isValidColor(value) || namedColorRegex.MatchString(value) || isValidURL(value)
+}
+
+// isValidTagStyle reports whether style is empty or one of the Bulma tag colors Homer
+// styles item tags with, such as "is-success". Other values render as a plain tag.
+func isValidTagStyle(style string) bool {
+	return style == "" || slices.Contains(tagStyles, style)
+}
+
+var tagStyles = []string{
+	"is-white", "is-black", "is-light", "is-dark", "is-primary",
+	"is-link", "is-info", "is-success", "is-warning", "is-danger",
+}
+
+var (
+	cssClassRegex   = regexp.MustCompile(`^-?[_a-zA-Z][_a-zA-Z0-9-]*$`)
+	namedColorRegex = regexp.MustCompile(`^[a-zA-Z]+$`)
+)
+
+// isValidHotkey reports whether key names a single key as reported by KeyboardEvent.key:
+// one printable character such as "/", or a key name such as "Shift" or "F2".
+func isValidHotkey(key string) bool {
+	runes := []rune(key)
+	switch {
+	case len(runes) == 0:
+		return true
+	case len(runes) == 1:
+		return unicode.IsPrint(runes[0]) && !unicode.IsSpace(runes[0])
+	case !unicode.IsUpper(runes[0]):
+		return false
+	}
+	for _, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidURL reports whether value is an absolute http or https URL with a host.
+func isValidURL(value string) bool {
+	parsed, err := url.ParseRequestURI(value)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// findDuplicateItems reports items that share a name within a service group but come
+// from different sources, since Homer renders them as look-alike tiles and a later
+// update by name would collapse them into one.
+func findDuplicateItems(config *HomerConfig) []string {
+	var warnings []string
+	for _, service := range config.Services {
+		sources := map[string]string{}
+		for _, item := range service.Items {
+			source := item.Source
+			if source == "" {
+				source = "dashboard"
+			}
+			first, seen := sources[item.Name]
+			if !seen {
+				sources[item.Name] = source
+				continue
+			}
+			if first != source {
+				warnings = append(warnings, fmt.Sprintf("service %q: item %q is defined by both %s and %s",
+					service.Name, item.Name, first, source))
+			}
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,98 @@
+package homer
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIsValidHotkey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{key: "", want: true},
+		{key: "/", want: true},
+		{key: "k", want: true},
+		{key: "Shift", want: true},
+		{key: "F2", want: true},
+		{key: " ", want: false},
+		{key: "ctrl+k", want: false},
+		{key: "search", want: false},
+	}
+	for _, tt := range tests {
+		if got := isValidHotkey(tt.key); got != tt.want {
+			t.Errorf("isValidHotkey(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestIsValidItemStyle(t *testing.T) {
+	tests := []struct {
+		field string
+		value string
+		want  bool
+	}{
+		{field: "class", value: "", want: true},
+		{field: "class", value: "highlight", want: true},
+		{field: "class", value: "is-info card-large", want: true},
+		{field: "class", value: "1st", want: false},
+		{field: "class", value: "a;b", want: false},
+		{field: "background", value: "#1e1e2e", want: true},
+		{field: "background", value: "rgb(30, 30, 46)", want: true},
+		{field: "background", value: "teal", want: true},
+		{field: "background", value: "https://example.com/bg.png", want: true},
+		{field: "background", value: "red; display: none", want: false},
+		{field: "tagstyle", value: "", want: true},
+		{field: "tagstyle", value: "is-success", want: true},
+		{field: "tagstyle", value: "is-sucess", want: false},
+		{field: "tagstyle", value: "success", want: false},
+	}
+	for _, tt := range tests {
+		if got := isValidItemStyle(tt.field, tt.value); got != tt.want {
+			t.Errorf("isValidItemStyle(%q, %q) = %v, want %v", tt.field, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestValidateHomerConfigTitle(t *testing.T) {
+	if warnings := ValidateHomerConfig(&HomerConfig{}); len(warnings) != 1 || warnings[0] != "title is empty" {
+		t.Errorf("expected a warning for the empty title, got %q", warnings)
+	}
+	if warnings := ValidateHomerConfig(&HomerConfig{Title: "Dashboard"}); len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %q", warnings)
+	}
+}
+
+func TestValidateHomerConfigDefaults(t *testing.T) {
+	config := HomerConfig{Title: "Dashboard", Defaults: DefaultConfig{Layout: "list", ColorTheme: "auto"}}
+	if warnings := ValidateHomerConfig(&config); len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %q", warnings)
+	}
+	config.Defaults = DefaultConfig{Layout: "grid", ColorTheme: "sepia"}
+	want := []string{
+		`defaults.layout "grid" is neither columns nor list`,
+		`defaults.colorTheme "sepia" is not one of auto, light or dark`,
+	}
+	if warnings := ValidateHomerConfig(&config); !slices.Equal(warnings, want) {
+		t.Errorf("expected warnings %q, got %q", want, warnings)
+	}
+}
+
+func TestValidateHomerConfigDuplicateItems(t *testing.T) {
+	config := HomerConfig{Title: "Dashboard", Services: []Service{{Name: "monitoring", Items: []Item{
+		{Name: "grafana"},
+		{Name: "grafana", Source: "ingress/monitoring/grafana"},
+		{Name: "prometheus", Source: "ingress/monitoring/prometheus"},
+		{Name: "prometheus", Source: "ingressroute/monitoring/prometheus"},
+		{Name: "alertmanager", Source: "ingress/monitoring/alertmanager"},
+		{Name: "alertmanager", Source: "ingress/monitoring/alertmanager"},
+	}}}}
+	want := []string{
+		`service "monitoring": item "grafana" is defined by both dashboard and ingress/monitoring/grafana`,
+		`service "monitoring": item "prometheus" is defined by both ingress/monitoring/prometheus and ` +
+			`ingressroute/monitoring/prometheus`,
+	}
+	if warnings := ValidateHomerConfig(&config); !slices.Equal(warnings, want) {
+		t.Errorf("expected warnings %q, got %q", want, warnings)
+	}
+}
@@ -0,0 +1,39 @@
+package homer
+
+import "time"
+
+// ApplyVisibilityWindows removes the items whose visibility window does not include now.
+// It returns the time until the next window of any item opens or closes, or 0 when no
+// window changes in the future, so the caller can render the config again then.
+func ApplyVisibilityWindows(config *HomerConfig, now time.Time) time.Duration {
+	var next time.Duration
+	wake := func(at time.Time) {
+		if at.After(now) && (next == 0 || at.Sub(now) < next) {
+			next = at.Sub(now)
+		}
+	}
+	for i, service := range config.Services {
+		if len(service.Items) == 0 {
+			continue
+		}
+		items := make([]Item, 0, len(service.Items))
+		for _, item := range service.Items {
+			wake(item.VisibleFrom)
+			wake(item.VisibleUntil)
+			if isItemVisible(item, now) {
+				items = append(items, item)
+			}
+		}
+		config.Services[i].Items = items
+	}
+	return next
+}
+
+// isItemVisible reports whether now is inside the visibility window of item. The window
+// includes VisibleFrom and excludes VisibleUntil; an unset bound is open.
+func isItemVisible(item Item, now time.Time) bool {
+	if !item.VisibleFrom.IsZero() && now.Before(item.VisibleFrom) {
+		return false
+	}
+	return item.VisibleUntil.IsZero() || now.Before(item.VisibleUntil)
+}
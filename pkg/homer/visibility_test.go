@@ -0,0 +1,50 @@
+package homer
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApplyVisibilityWindows(t *testing.T) {
+	now := time.Date(2024, 12, 1, 12, 0, 0, 0, time.UTC)
+	config := HomerConfig{Services: []Service{{Name: "seasonal", Items: []Item{
+		{Name: "always"},
+		{Name: "advent", VisibleFrom: now.Add(-time.Hour), VisibleUntil: now.Add(24 * time.Hour)},
+		{Name: "new-year", VisibleFrom: now.Add(2 * time.Hour)},
+		{Name: "halloween", VisibleUntil: now.Add(-time.Hour)},
+		{Name: "boundary", VisibleUntil: now},
+	}}}}
+	next := ApplyVisibilityWindows(&config, now)
+	if got := itemNames(config.Services[0].Items); !slices.Equal(got, []string{"always", "advent"}) {
+		t.Errorf("expected only the items in their window, got %v", got)
+	}
+	if next != 2*time.Hour {
+		t.Errorf("expected to wake up when the next window opens, got %v", next)
+	}
+	if next := ApplyVisibilityWindows(&HomerConfig{Services: []Service{{Name: "empty"}}}, now); next != 0 {
+		t.Errorf("expected no wake up without windows, got %v", next)
+	}
+}
+
+func TestCreateIngressItemVisibility(t *testing.T) {
+	ingress := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Name:      "advent",
+		Namespace: "default",
+		Annotations: map[string]string{
+			"item.homer.rajsingh.info/visible-from":  "2024-12-01T00:00:00Z",
+			"item.homer.rajsingh.info/visible-until": "Christmas",
+		},
+	}}
+	_, item := createIngressItem(ingress, "advent.example.com", DiscoveryOptions{Logger: logr.Discard()})
+	if !item.VisibleFrom.Equal(time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the visible-from time, got %v", item.VisibleFrom)
+	}
+	if !item.VisibleUntil.IsZero() {
+		t.Errorf("expected the invalid visible-until to be ignored, got %v", item.VisibleUntil)
+	}
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// MatchesHostDomainFilters reports whether host passes the domain filters. A filter
+// starting with ! excludes the hosts it matches, and exclusions win over inclusions.
+// A host passes when no exclusion matches it and it matches an inclusion, or when
+// there are no inclusions at all. An empty list of filters matches every host.
+func MatchesHostDomainFilters(host string, filters []string) bool {
+	included, hasInclusions := false, false
+	for _, filter := range filters {
+		if exclusion, ok := strings.CutPrefix(filter, "!"); ok {
+			if MatchesDomainFilter(host, exclusion) {
+				return false
+			}
+			continue
+		}
+		hasInclusions = true
+		if !included && MatchesDomainFilter(host, filter) {
+			included = true
+		}
+	}
+	return included || !hasInclusions
+}
+
+// MatchesDomainFilter reports whether host matches a single domain filter. A filter is
+//   - a domain, matching the domain itself and all of its subdomains: example.com
+//   - a glob, where * matches any run of characters: *.staging.example.com
+//   - a regular expression between slashes: /^app-[0-9]+\.example\.com$/
+//
+// Hosts and non-regex filters are compared case-insensitively. A filter that is not a
+// valid glob or regular expression matches nothing.
+func MatchesDomainFilter(host, filter string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if !isRegexpPattern(filter) {
+		filter = strings.ToLower(strings.TrimSuffix(filter, "."))
+	}
+	if matched, ok := matchPattern(host, filter); ok {
+		return matched
+	}
+	return host == filter || strings.HasSuffix(host, "."+filter)
+}
+
+// MatchesAnyDomainFilter reports whether host matches one of filters, see MatchesDomainFilter.
+func MatchesAnyDomainFilter(host string, filters []string) bool {
+	for _, filter := range filters {
+		if MatchesDomainFilter(host, filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesNamePatterns reports whether name matches one of patterns. A pattern is a glob
+// such as *-canary-*, a regular expression between slashes, or else a name compared exactly.
+func MatchesNamePatterns(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		matched, ok := matchPattern(name, pattern)
+		if !ok {
+			matched = name == pattern
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern matches value against pattern when pattern is a regular expression
+// between slashes or a glob, reporting in ok whether it was either. A pattern that is
+// not a valid glob or regular expression matches nothing.
+func matchPattern(value, pattern string) (matched bool, ok bool) {
+	if isRegexpPattern(pattern) {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		return err == nil && re.MatchString(value), true
+	}
+	if strings.ContainsAny(pattern, "*?[") {
+		matched, err := path.Match(pattern, value)
+		return err == nil && matched, true
+	}
+	return false, false
+}
+
+func isRegexpPattern(pattern string) bool {
+	return len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/")
+}
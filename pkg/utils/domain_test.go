@@ -0,0 +1,84 @@
+package utils
+
+import "testing"
+
+func TestMatchesDomainFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		host   string
+		filter string
+		want   bool
+	}{
+		{name: "exact", host: "example.com", filter: "example.com", want: true},
+		{name: "exact case-insensitive", host: "App.Example.com", filter: "app.example.COM", want: true},
+		{name: "suffix", host: "app.example.com", filter: "example.com", want: true},
+		{name: "suffix needs a label boundary", host: "notexample.com", filter: "example.com", want: false},
+		{name: "wildcard subdomain", host: "app.staging.example.com", filter: "*.staging.example.com", want: true},
+		{name: "wildcard excludes the apex", host: "staging.example.com", filter: "*.staging.example.com", want: false},
+		{name: "wildcard other domain", host: "app.example.com", filter: "*.staging.example.com", want: false},
+		{name: "wildcard in a label", host: "app-1.example.com", filter: "app-*.example.com", want: true},
+		{name: "regex", host: "app-42.example.com", filter: `/^app-[0-9]+\.example\.com$/`, want: true},
+		{name: "regex no match", host: "app-x.example.com", filter: `/^app-[0-9]+\.example\.com$/`, want: false},
+		{name: "invalid regex", host: "example.com", filter: "/(/", want: false},
+		{name: "trailing dot", host: "example.com.", filter: "example.com", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchesDomainFilter(tt.host, tt.filter); got != tt.want {
+				t.Errorf("MatchesDomainFilter(%q, %q) = %v, want %v", tt.host, tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesHostDomainFilters(t *testing.T) {
+	withExclusion := []string{"example.com", "!internal.example.com"}
+	exclusionFirst := []string{"!internal.example.com", "example.com"}
+	onlyExclusion := []string{"!*.internal.example.com"}
+	regexExclusion := []string{"example.com", `!/^app-[0-9]+\./`}
+	tests := []struct {
+		name    string
+		host    string
+		filters []string
+		want    bool
+	}{
+		{name: "no filters", host: "example.com", want: true},
+		{name: "inclusion", host: "app.example.com", filters: []string{"example.com"}, want: true},
+		{name: "no inclusion matches", host: "app.example.org", filters: []string{"example.com"}, want: false},
+		{name: "exclusion wins", host: "app.internal.example.com", filters: withExclusion, want: false},
+		{name: "exclusion wins in any order", host: "internal.example.com", filters: exclusionFirst, want: false},
+		{name: "not excluded", host: "app.example.com", filters: withExclusion, want: true},
+		{name: "only exclusions", host: "app.example.org", filters: onlyExclusion, want: true},
+		{name: "only exclusions match", host: "db.internal.example.com", filters: onlyExclusion, want: false},
+		{name: "regex exclusion", host: "app-1.example.com", filters: regexExclusion, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchesHostDomainFilters(tt.host, tt.filters); got != tt.want {
+				t.Errorf("MatchesHostDomainFilters(%q, %q) = %v, want %v", tt.host, tt.filters, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesNamePatterns(t *testing.T) {
+	patterns := []string{"*-canary-*", "acme-http-solver-*", `/^tmp-[0-9]+$/`, "debug"}
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "web-canary-1", want: true},
+		{name: "acme-http-solver-x7k2p", want: true},
+		{name: "tmp-42", want: true},
+		{name: "debug", want: true},
+		{name: "debug-ui", want: false},
+		{name: "web", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchesNamePatterns(tt.name, patterns); got != tt.want {
+				t.Errorf("MatchesNamePatterns(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
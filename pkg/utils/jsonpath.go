@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LookupJSONPath returns the value at path in the JSON document data. The path is a
+// dotted list of object keys and array indices such as token or auth.tokens.0, with an
+// optional leading $. as in JSONPath. Strings are returned as is, and any other value
+// as JSON.
+func LookupJSONPath(data []byte, path string) (string, error) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", fmt.Errorf("value is not JSON: %w", err)
+	}
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	for _, segment := range strings.Split(path, ".") {
+		switch node := value.(type) {
+		case map[string]interface{}:
+			child, ok := node[segment]
+			if !ok {
+				return "", fmt.Errorf("key %q not found in %q", segment, path)
+			}
+			value = child
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return "", fmt.Errorf("index %q out of range in %q", segment, path)
+			}
+			value = node[index]
+		default:
+			return "", fmt.Errorf("cannot look up %q in a scalar in %q", segment, path)
+		}
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	out, err := json.Marshal(value)
+	return string(out), err
+}
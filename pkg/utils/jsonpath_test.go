@@ -0,0 +1,30 @@
+package utils
+
+import "testing"
+
+func TestLookupJSONPath(t *testing.T) {
+	data := []byte(`{"token":"abc","auth":{"tokens":["first","second"],"port":8080}}`)
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "token", want: "abc"},
+		{path: "$.token", want: "abc"},
+		{path: ".auth.tokens.1", want: "second"},
+		{path: "auth.port", want: "8080"},
+		{path: "auth.tokens", want: `["first","second"]`},
+		{path: "missing", wantErr: true},
+		{path: "auth.tokens.2", wantErr: true},
+		{path: "token.value", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := LookupJSONPath(data, tt.path)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("LookupJSONPath(%q) = %q, %v, want %q, error %v", tt.path, got, err, tt.want, tt.wantErr)
+		}
+	}
+	if _, err := LookupJSONPath([]byte("not json"), "token"); err == nil {
+		t.Error("expected an error for a value that is not JSON")
+	}
+}